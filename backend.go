@@ -0,0 +1,16 @@
+package openai
+
+import "context"
+
+// Backend is the common interface implemented by every chat completion
+// provider this module can talk to, including Client and MockBackend.
+type Backend interface {
+	Name() string
+	ChatCompletion(ctx context.Context, req ChatCompletionRequest) (*ChatCompletionResponse, error)
+	ChatCompletionStream(ctx context.Context, req ChatCompletionRequest) (*ChatCompletionStream, error)
+	SendMessage(ctx context.Context, req Request) (*Response, error)
+	CreateEmbeddings(ctx context.Context, req EmbeddingRequest) (*EmbeddingResponse, error)
+	Moderations(ctx context.Context, req ModerationRequest) (*ModerationResponse, error)
+	IsAvailable(ctx context.Context) bool
+	Configure(config map[string]interface{}) error
+}
@@ -0,0 +1,260 @@
+// Package backends holds Backend implementations for providers other than
+// OpenAI itself, translating openai.ChatCompletionRequest/Response to and
+// from each vendor's native schema. Importing this package for its init-time
+// openai.RegisterBackend calls is enough to make every provider in it
+// available to openai.NewBackendByName.
+package backends
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	openai "github.com/jeanhaley32/go-openai-client"
+)
+
+func init() {
+	openai.RegisterBackend("anthropic", func(config openai.Config) (openai.Backend, error) {
+		return NewAnthropicBackend(config), nil
+	})
+}
+
+// AnthropicBackend implements openai.Backend against Anthropic's Messages
+// API, translating the shared ChatCompletionRequest/Response shape to and
+// from Anthropic's `messages` + separate `system` schema.
+type AnthropicBackend struct {
+	apiKey     string
+	baseURL    string
+	model      string
+	httpClient *http.Client
+	maxRetries int
+	sleep      func(time.Duration)
+}
+
+// NewAnthropicBackend creates a Backend that talks to Anthropic's API.
+func NewAnthropicBackend(config openai.Config) *AnthropicBackend {
+	if config.BaseURL == "" {
+		config.BaseURL = "https://api.anthropic.com/v1"
+	}
+	if config.Timeout == 0 {
+		config.Timeout = 30 * time.Second
+	}
+
+	return &AnthropicBackend{
+		apiKey:     config.APIKey,
+		baseURL:    config.BaseURL,
+		model:      config.Model,
+		httpClient: &http.Client{Timeout: config.Timeout},
+		maxRetries: config.MaxRetries,
+		sleep:      time.Sleep,
+	}
+}
+
+// Name returns the name of this backend.
+func (a *AnthropicBackend) Name() string {
+	return "Anthropic"
+}
+
+// SetSleepFunc overrides the delay function used between retries, so tests
+// can exercise the retry loop without real backoff waits.
+func (a *AnthropicBackend) SetSleepFunc(sleep func(time.Duration)) {
+	a.sleep = sleep
+}
+
+type anthropicMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type anthropicRequest struct {
+	Model       string             `json:"model"`
+	System      string             `json:"system,omitempty"`
+	Messages    []anthropicMessage `json:"messages"`
+	MaxTokens   int                `json:"max_tokens"`
+	Temperature *float64           `json:"temperature,omitempty"`
+	TopP        *float64           `json:"top_p,omitempty"`
+}
+
+type anthropicContentBlock struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type anthropicResponse struct {
+	Content    []anthropicContentBlock `json:"content"`
+	Model      string                  `json:"model"`
+	StopReason string                  `json:"stop_reason"`
+	Usage      struct {
+		InputTokens  int `json:"input_tokens"`
+		OutputTokens int `json:"output_tokens"`
+	} `json:"usage"`
+}
+
+func toAnthropicRequest(req openai.ChatCompletionRequest) anthropicRequest {
+	areq := anthropicRequest{
+		Model:       req.Model,
+		Temperature: req.Temperature,
+		TopP:        req.TopP,
+		// Anthropic requires max_tokens; fall back to a sane default when the
+		// caller didn't set one.
+		MaxTokens: 1024,
+	}
+	if req.MaxTokens != nil {
+		areq.MaxTokens = *req.MaxTokens
+	}
+
+	for _, msg := range req.Messages {
+		if msg.Role == "system" {
+			areq.System = msg.Content
+			continue
+		}
+		areq.Messages = append(areq.Messages, anthropicMessage{Role: msg.Role, Content: msg.Content})
+	}
+
+	return areq
+}
+
+func fromAnthropicResponse(resp anthropicResponse) *openai.ChatCompletionResponse {
+	var text string
+	for _, block := range resp.Content {
+		if block.Type == "text" {
+			text += block.Text
+		}
+	}
+
+	return &openai.ChatCompletionResponse{
+		Object: "chat.completion",
+		Model:  resp.Model,
+		Choices: []openai.Choice{
+			{
+				Index:        0,
+				Message:      openai.Message{Role: "assistant", Content: text},
+				FinishReason: resp.StopReason,
+			},
+		},
+		Usage: openai.Usage{
+			PromptTokens:     resp.Usage.InputTokens,
+			CompletionTokens: resp.Usage.OutputTokens,
+			TotalTokens:      resp.Usage.InputTokens + resp.Usage.OutputTokens,
+		},
+	}
+}
+
+// ChatCompletion sends a chat completion request to Anthropic's Messages API.
+func (a *AnthropicBackend) ChatCompletion(ctx context.Context, req openai.ChatCompletionRequest) (*openai.ChatCompletionResponse, error) {
+	if req.Model == "" {
+		return nil, fmt.Errorf("model is required")
+	}
+	if len(req.Messages) == 0 {
+		return nil, fmt.Errorf("messages are required")
+	}
+
+	requestBody, err := json.Marshal(toAnthropicRequest(req))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/messages", a.baseURL)
+	_, responseBody, err := openai.DoWithRetry(a.httpClient, a.maxRetries, a.sleep, func() (*http.Request, error) {
+		httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(requestBody))
+		if err != nil {
+			return nil, err
+		}
+		httpReq.Header.Set("Content-Type", "application/json")
+		httpReq.Header.Set("x-api-key", a.apiKey)
+		httpReq.Header.Set("anthropic-version", "2023-06-01")
+		return httpReq, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var anthropicResp anthropicResponse
+	if err := json.Unmarshal(responseBody, &anthropicResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return fromAnthropicResponse(anthropicResp), nil
+}
+
+// ChatCompletionStream is not yet implemented for Anthropic.
+func (a *AnthropicBackend) ChatCompletionStream(ctx context.Context, req openai.ChatCompletionRequest) (*openai.ChatCompletionStream, error) {
+	return nil, errStreamingNotSupported("anthropic")
+}
+
+// CreateEmbeddings is not supported: Anthropic has no embeddings endpoint.
+func (a *AnthropicBackend) CreateEmbeddings(ctx context.Context, req openai.EmbeddingRequest) (*openai.EmbeddingResponse, error) {
+	return nil, fmt.Errorf("anthropic: embeddings are not supported")
+}
+
+// Moderations is not supported: Anthropic has no moderations endpoint.
+func (a *AnthropicBackend) Moderations(ctx context.Context, req openai.ModerationRequest) (*openai.ModerationResponse, error) {
+	return nil, fmt.Errorf("anthropic: moderations are not supported")
+}
+
+// SendMessage implements the legacy interface by converting to ChatCompletion.
+func (a *AnthropicBackend) SendMessage(ctx context.Context, req openai.Request) (*openai.Response, error) {
+	chatReq := openai.ChatCompletionRequest{
+		Model:       req.Model,
+		Messages:    req.Messages,
+		MaxTokens:   req.MaxTokens,
+		Temperature: req.Temperature,
+		TopP:        req.TopP,
+	}
+	if chatReq.Model == "" {
+		chatReq.Model = a.model
+	}
+
+	chatResp, err := a.ChatCompletion(ctx, chatReq)
+	if err != nil {
+		return &openai.Response{Error: err}, err
+	}
+
+	return &openai.Response{
+		Content:    chatResp.Choices[0].Message.Content,
+		TokensUsed: chatResp.Usage.TotalTokens,
+		Model:      chatResp.Model,
+		Timestamp:  time.Now(),
+	}, nil
+}
+
+// IsAvailable checks if the Anthropic API is reachable.
+func (a *AnthropicBackend) IsAvailable(ctx context.Context) bool {
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/messages", a.baseURL), bytes.NewBufferString("{}"))
+	if err != nil {
+		return false
+	}
+	req.Header.Set("x-api-key", a.apiKey)
+	req.Header.Set("anthropic-version", "2023-06-01")
+
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return false
+	}
+	defer resp.Body.Close()
+
+	// A malformed empty body still proves the endpoint is reachable; only a
+	// transport failure or a 404 means "unavailable".
+	return resp.StatusCode != http.StatusNotFound
+}
+
+// Configure updates the backend configuration.
+func (a *AnthropicBackend) Configure(config map[string]interface{}) error {
+	if apiKey, ok := config["api_key"].(string); ok && apiKey != "" {
+		a.apiKey = apiKey
+	}
+	if baseURL, ok := config["base_url"].(string); ok && baseURL != "" {
+		a.baseURL = baseURL
+	}
+	if model, ok := config["model"].(string); ok && model != "" {
+		a.model = model
+	}
+
+	if a.apiKey == "" {
+		return fmt.Errorf("api_key is required")
+	}
+	return nil
+}
@@ -0,0 +1,224 @@
+package backends
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	openai "github.com/jeanhaley32/go-openai-client"
+)
+
+func init() {
+	openai.RegisterBackend("azure", func(config openai.Config) (openai.Backend, error) {
+		return NewAzureBackend(AzureConfig{Config: config}), nil
+	})
+}
+
+// AzureConfig configures an AzureBackend. Deployment and APIVersion have no
+// equivalent in the shared openai.Config, since they're specific to Azure's
+// per-deployment URL scheme.
+type AzureConfig struct {
+	openai.Config
+	Deployment string
+	APIVersion string
+}
+
+// AzureBackend implements openai.Backend against Azure OpenAI, which speaks
+// the same request/response schema as OpenAI but resolves a deployment name
+// to a model and requires an api-version query parameter and api-key header
+// instead of a bearer token.
+type AzureBackend struct {
+	apiKey     string
+	endpoint   string
+	deployment string
+	apiVersion string
+	httpClient *http.Client
+	maxRetries int
+	sleep      func(time.Duration)
+}
+
+// NewAzureBackend creates a Backend that talks to an Azure OpenAI deployment.
+func NewAzureBackend(config AzureConfig) *AzureBackend {
+	if config.Timeout == 0 {
+		config.Timeout = 30 * time.Second
+	}
+	if config.APIVersion == "" {
+		config.APIVersion = "2024-02-01"
+	}
+
+	return &AzureBackend{
+		apiKey:     config.APIKey,
+		endpoint:   config.BaseURL,
+		deployment: config.Deployment,
+		apiVersion: config.APIVersion,
+		httpClient: &http.Client{Timeout: config.Timeout},
+		maxRetries: config.MaxRetries,
+		sleep:      time.Sleep,
+	}
+}
+
+// Name returns the name of this backend.
+func (a *AzureBackend) Name() string {
+	return "Azure OpenAI"
+}
+
+// SetSleepFunc overrides the delay function used between retries, so tests
+// can exercise the retry loop without real backoff waits.
+func (a *AzureBackend) SetSleepFunc(sleep func(time.Duration)) {
+	a.sleep = sleep
+}
+
+func (a *AzureBackend) url() string {
+	return fmt.Sprintf("%s/openai/deployments/%s/chat/completions?api-version=%s", a.endpoint, a.deployment, a.apiVersion)
+}
+
+// ChatCompletion sends a chat completion request to the configured Azure
+// OpenAI deployment.
+func (a *AzureBackend) ChatCompletion(ctx context.Context, req openai.ChatCompletionRequest) (*openai.ChatCompletionResponse, error) {
+	if len(req.Messages) == 0 {
+		return nil, fmt.Errorf("messages are required")
+	}
+	if a.deployment == "" {
+		return nil, fmt.Errorf("azure deployment is required")
+	}
+
+	requestBody, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	_, responseBody, err := openai.DoWithRetry(a.httpClient, a.maxRetries, a.sleep, func() (*http.Request, error) {
+		httpReq, err := http.NewRequestWithContext(ctx, "POST", a.url(), bytes.NewBuffer(requestBody))
+		if err != nil {
+			return nil, err
+		}
+		httpReq.Header.Set("Content-Type", "application/json")
+		httpReq.Header.Set("api-key", a.apiKey)
+		return httpReq, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var chatResp openai.ChatCompletionResponse
+	if err := json.Unmarshal(responseBody, &chatResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &chatResp, nil
+}
+
+// ChatCompletionStream is not yet implemented for Azure OpenAI.
+func (a *AzureBackend) ChatCompletionStream(ctx context.Context, req openai.ChatCompletionRequest) (*openai.ChatCompletionStream, error) {
+	return nil, errStreamingNotSupported("azure")
+}
+
+// CreateEmbeddings sends an embeddings request to the configured Azure
+// OpenAI embeddings deployment.
+func (a *AzureBackend) CreateEmbeddings(ctx context.Context, req openai.EmbeddingRequest) (*openai.EmbeddingResponse, error) {
+	if req.Input == nil {
+		return nil, fmt.Errorf("input is required")
+	}
+	if a.deployment == "" {
+		return nil, fmt.Errorf("azure deployment is required")
+	}
+
+	requestBody, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/openai/deployments/%s/embeddings?api-version=%s", a.endpoint, a.deployment, a.apiVersion)
+	_, responseBody, err := openai.DoWithRetry(a.httpClient, a.maxRetries, a.sleep, func() (*http.Request, error) {
+		httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(requestBody))
+		if err != nil {
+			return nil, err
+		}
+		httpReq.Header.Set("Content-Type", "application/json")
+		httpReq.Header.Set("api-key", a.apiKey)
+		return httpReq, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var embeddingResp openai.EmbeddingResponse
+	if err := json.Unmarshal(responseBody, &embeddingResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &embeddingResp, nil
+}
+
+// Moderations is not supported: Azure exposes content filtering through its
+// separate Content Safety API, not an OpenAI-shaped /moderations endpoint.
+func (a *AzureBackend) Moderations(ctx context.Context, req openai.ModerationRequest) (*openai.ModerationResponse, error) {
+	return nil, fmt.Errorf("azure: moderations endpoint is not supported, use Azure Content Safety instead")
+}
+
+// SendMessage implements the legacy interface by converting to ChatCompletion.
+func (a *AzureBackend) SendMessage(ctx context.Context, req openai.Request) (*openai.Response, error) {
+	chatResp, err := a.ChatCompletion(ctx, openai.ChatCompletionRequest{
+		Model:       req.Model,
+		Messages:    req.Messages,
+		MaxTokens:   req.MaxTokens,
+		Temperature: req.Temperature,
+		TopP:        req.TopP,
+	})
+	if err != nil {
+		return &openai.Response{Error: err}, err
+	}
+	if len(chatResp.Choices) == 0 {
+		err := fmt.Errorf("no response choices returned")
+		return &openai.Response{Error: err}, err
+	}
+
+	return &openai.Response{
+		Content:    chatResp.Choices[0].Message.Content,
+		TokensUsed: chatResp.Usage.TotalTokens,
+		Model:      chatResp.Model,
+		Timestamp:  time.Now(),
+	}, nil
+}
+
+// IsAvailable checks if the configured Azure OpenAI deployment is reachable.
+func (a *AzureBackend) IsAvailable(ctx context.Context) bool {
+	req, err := http.NewRequestWithContext(ctx, "POST", a.url(), bytes.NewBufferString("{}"))
+	if err != nil {
+		return false
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("api-key", a.apiKey)
+
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return false
+	}
+	defer resp.Body.Close()
+
+	return resp.StatusCode != http.StatusNotFound
+}
+
+// Configure updates the backend configuration.
+func (a *AzureBackend) Configure(config map[string]interface{}) error {
+	if apiKey, ok := config["api_key"].(string); ok && apiKey != "" {
+		a.apiKey = apiKey
+	}
+	if endpoint, ok := config["base_url"].(string); ok && endpoint != "" {
+		a.endpoint = endpoint
+	}
+	if deployment, ok := config["deployment"].(string); ok && deployment != "" {
+		a.deployment = deployment
+	}
+	if apiVersion, ok := config["api_version"].(string); ok && apiVersion != "" {
+		a.apiVersion = apiVersion
+	}
+
+	if a.apiKey == "" {
+		return fmt.Errorf("api_key is required")
+	}
+	return nil
+}
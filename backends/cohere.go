@@ -0,0 +1,262 @@
+package backends
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	openai "github.com/jeanhaley32/go-openai-client"
+)
+
+func init() {
+	openai.RegisterBackend("cohere", func(config openai.Config) (openai.Backend, error) {
+		return NewCohereBackend(config), nil
+	})
+}
+
+// CohereBackend implements openai.Backend against Cohere's Chat API,
+// translating the shared ChatCompletionRequest/Response shape to and from
+// Cohere's `chat_history` + `message` schema.
+type CohereBackend struct {
+	apiKey     string
+	baseURL    string
+	model      string
+	httpClient *http.Client
+	maxRetries int
+	sleep      func(time.Duration)
+}
+
+// NewCohereBackend creates a Backend that talks to Cohere's API.
+func NewCohereBackend(config openai.Config) *CohereBackend {
+	if config.BaseURL == "" {
+		config.BaseURL = "https://api.cohere.ai/v1"
+	}
+	if config.Timeout == 0 {
+		config.Timeout = 30 * time.Second
+	}
+
+	return &CohereBackend{
+		apiKey:     config.APIKey,
+		baseURL:    config.BaseURL,
+		model:      config.Model,
+		httpClient: &http.Client{Timeout: config.Timeout},
+		maxRetries: config.MaxRetries,
+		sleep:      time.Sleep,
+	}
+}
+
+// Name returns the name of this backend.
+func (c *CohereBackend) Name() string {
+	return "Cohere"
+}
+
+// SetSleepFunc overrides the delay function used between retries, so tests
+// can exercise the retry loop without real backoff waits.
+func (c *CohereBackend) SetSleepFunc(sleep func(time.Duration)) {
+	c.sleep = sleep
+}
+
+type cohereHistoryEntry struct {
+	Role    string `json:"role"`
+	Message string `json:"message"`
+}
+
+type cohereRequest struct {
+	Model       string               `json:"model"`
+	Message     string               `json:"message"`
+	Preamble    string               `json:"preamble,omitempty"`
+	ChatHistory []cohereHistoryEntry `json:"chat_history,omitempty"`
+	Temperature *float64             `json:"temperature,omitempty"`
+	P           *float64             `json:"p,omitempty"`
+	MaxTokens   *int                 `json:"max_tokens,omitempty"`
+}
+
+type cohereResponse struct {
+	Text  string `json:"text"`
+	Model string `json:"model,omitempty"`
+	Meta  struct {
+		Tokens struct {
+			InputTokens  float64 `json:"input_tokens"`
+			OutputTokens float64 `json:"output_tokens"`
+		} `json:"tokens"`
+	} `json:"meta"`
+}
+
+// cohereRole maps an openai.Message role to Cohere's chat_history role.
+func cohereRole(role string) string {
+	if role == "assistant" {
+		return "CHATBOT"
+	}
+	return "USER"
+}
+
+func toCohereRequest(req openai.ChatCompletionRequest) (cohereRequest, error) {
+	if len(req.Messages) == 0 {
+		return cohereRequest{}, fmt.Errorf("messages are required")
+	}
+
+	creq := cohereRequest{
+		Model:       req.Model,
+		Temperature: req.Temperature,
+		P:           req.TopP,
+		MaxTokens:   req.MaxTokens,
+	}
+
+	for i, msg := range req.Messages {
+		if msg.Role == "system" {
+			creq.Preamble = msg.Content
+			continue
+		}
+		if i == len(req.Messages)-1 {
+			creq.Message = msg.Content
+			continue
+		}
+		creq.ChatHistory = append(creq.ChatHistory, cohereHistoryEntry{
+			Role:    cohereRole(msg.Role),
+			Message: msg.Content,
+		})
+	}
+
+	return creq, nil
+}
+
+func fromCohereResponse(resp cohereResponse, model string) *openai.ChatCompletionResponse {
+	if resp.Model != "" {
+		model = resp.Model
+	}
+
+	return &openai.ChatCompletionResponse{
+		Object: "chat.completion",
+		Model:  model,
+		Choices: []openai.Choice{
+			{
+				Index:        0,
+				Message:      openai.Message{Role: "assistant", Content: resp.Text},
+				FinishReason: "stop",
+			},
+		},
+		Usage: openai.Usage{
+			PromptTokens:     int(resp.Meta.Tokens.InputTokens),
+			CompletionTokens: int(resp.Meta.Tokens.OutputTokens),
+			TotalTokens:      int(resp.Meta.Tokens.InputTokens + resp.Meta.Tokens.OutputTokens),
+		},
+	}
+}
+
+// ChatCompletion sends a chat completion request to Cohere's Chat API.
+func (c *CohereBackend) ChatCompletion(ctx context.Context, req openai.ChatCompletionRequest) (*openai.ChatCompletionResponse, error) {
+	if req.Model == "" {
+		return nil, fmt.Errorf("model is required")
+	}
+
+	cohereReq, err := toCohereRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	requestBody, err := json.Marshal(cohereReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/chat", c.baseURL)
+	_, responseBody, err := openai.DoWithRetry(c.httpClient, c.maxRetries, c.sleep, func() (*http.Request, error) {
+		httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(requestBody))
+		if err != nil {
+			return nil, err
+		}
+		httpReq.Header.Set("Content-Type", "application/json")
+		httpReq.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.apiKey))
+		return httpReq, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var cohereResp cohereResponse
+	if err := json.Unmarshal(responseBody, &cohereResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return fromCohereResponse(cohereResp, req.Model), nil
+}
+
+// ChatCompletionStream is not yet implemented for Cohere.
+func (c *CohereBackend) ChatCompletionStream(ctx context.Context, req openai.ChatCompletionRequest) (*openai.ChatCompletionStream, error) {
+	return nil, errStreamingNotSupported("cohere")
+}
+
+// CreateEmbeddings is not yet implemented for Cohere, which has its own
+// /embed endpoint and request shape distinct from OpenAI's.
+func (c *CohereBackend) CreateEmbeddings(ctx context.Context, req openai.EmbeddingRequest) (*openai.EmbeddingResponse, error) {
+	return nil, fmt.Errorf("cohere: embeddings are not yet supported")
+}
+
+// Moderations is not supported: Cohere has no moderations endpoint.
+func (c *CohereBackend) Moderations(ctx context.Context, req openai.ModerationRequest) (*openai.ModerationResponse, error) {
+	return nil, fmt.Errorf("cohere: moderations are not supported")
+}
+
+// SendMessage implements the legacy interface by converting to ChatCompletion.
+func (c *CohereBackend) SendMessage(ctx context.Context, req openai.Request) (*openai.Response, error) {
+	chatReq := openai.ChatCompletionRequest{
+		Model:       req.Model,
+		Messages:    req.Messages,
+		MaxTokens:   req.MaxTokens,
+		Temperature: req.Temperature,
+		TopP:        req.TopP,
+	}
+	if chatReq.Model == "" {
+		chatReq.Model = c.model
+	}
+
+	chatResp, err := c.ChatCompletion(ctx, chatReq)
+	if err != nil {
+		return &openai.Response{Error: err}, err
+	}
+
+	return &openai.Response{
+		Content:    chatResp.Choices[0].Message.Content,
+		TokensUsed: chatResp.Usage.TotalTokens,
+		Model:      chatResp.Model,
+		Timestamp:  time.Now(),
+	}, nil
+}
+
+// IsAvailable checks if the Cohere API is reachable.
+func (c *CohereBackend) IsAvailable(ctx context.Context) bool {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/models", c.baseURL), nil)
+	if err != nil {
+		return false
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.apiKey))
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return false
+	}
+	defer resp.Body.Close()
+
+	return resp.StatusCode == http.StatusOK
+}
+
+// Configure updates the backend configuration.
+func (c *CohereBackend) Configure(config map[string]interface{}) error {
+	if apiKey, ok := config["api_key"].(string); ok && apiKey != "" {
+		c.apiKey = apiKey
+	}
+	if baseURL, ok := config["base_url"].(string); ok && baseURL != "" {
+		c.baseURL = baseURL
+	}
+	if model, ok := config["model"].(string); ok && model != "" {
+		c.model = model
+	}
+
+	if c.apiKey == "" {
+		return fmt.Errorf("api_key is required")
+	}
+	return nil
+}
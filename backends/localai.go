@@ -0,0 +1,12 @@
+package backends
+
+import "github.com/jeanhaley32/go-openai-client"
+
+func init() {
+	openai.RegisterBackend("localai", func(config openai.Config) (openai.Backend, error) {
+		if config.BaseURL == "" {
+			config.BaseURL = "http://localhost:8080/v1"
+		}
+		return openai.NewCompatibleClient(openai.CompatibleConfig{Config: config}), nil
+	})
+}
@@ -0,0 +1,115 @@
+package backends
+
+import (
+	"testing"
+
+	openai "github.com/jeanhaley32/go-openai-client"
+)
+
+func TestToAnthropicRequestMapsSystemMessageToSystemField(t *testing.T) {
+	req := openai.ChatCompletionRequest{
+		Model: "claude-3-opus",
+		Messages: []openai.Message{
+			{Role: "system", Content: "be terse"},
+			{Role: "user", Content: "hi"},
+		},
+	}
+
+	areq := toAnthropicRequest(req)
+
+	if areq.System != "be terse" {
+		t.Errorf("System = %q, want %q", areq.System, "be terse")
+	}
+	if len(areq.Messages) != 1 || areq.Messages[0].Role != "user" {
+		t.Errorf("Messages = %+v, want a single user message", areq.Messages)
+	}
+}
+
+func TestToAnthropicRequestDefaultsMaxTokens(t *testing.T) {
+	areq := toAnthropicRequest(openai.ChatCompletionRequest{
+		Model:    "claude-3-opus",
+		Messages: []openai.Message{{Role: "user", Content: "hi"}},
+	})
+
+	if areq.MaxTokens != 1024 {
+		t.Errorf("MaxTokens = %d, want default of 1024", areq.MaxTokens)
+	}
+}
+
+func TestFromAnthropicResponseConcatenatesTextBlocks(t *testing.T) {
+	resp := fromAnthropicResponse(anthropicResponse{
+		Model:      "claude-3-opus",
+		StopReason: "end_turn",
+		Content: []anthropicContentBlock{
+			{Type: "text", Text: "hello "},
+			{Type: "text", Text: "world"},
+		},
+	})
+
+	if len(resp.Choices) != 1 {
+		t.Fatalf("Choices = %d, want 1", len(resp.Choices))
+	}
+	if got := resp.Choices[0].Message.Content; got != "hello world" {
+		t.Errorf("Content = %q, want %q", got, "hello world")
+	}
+	if resp.Choices[0].FinishReason != "end_turn" {
+		t.Errorf("FinishReason = %q, want %q", resp.Choices[0].FinishReason, "end_turn")
+	}
+}
+
+func TestToCohereRequestMapsSystemMessageToPreamble(t *testing.T) {
+	req := openai.ChatCompletionRequest{
+		Model: "command-r",
+		Messages: []openai.Message{
+			{Role: "system", Content: "be terse"},
+			{Role: "user", Content: "earlier"},
+			{Role: "assistant", Content: "reply"},
+			{Role: "user", Content: "latest"},
+		},
+	}
+
+	creq, err := toCohereRequest(req)
+	if err != nil {
+		t.Fatalf("toCohereRequest returned error: %v", err)
+	}
+
+	if creq.Preamble != "be terse" {
+		t.Errorf("Preamble = %q, want %q", creq.Preamble, "be terse")
+	}
+	if creq.Message != "latest" {
+		t.Errorf("Message = %q, want %q", creq.Message, "latest")
+	}
+	if len(creq.ChatHistory) != 2 {
+		t.Fatalf("ChatHistory = %+v, want 2 entries", creq.ChatHistory)
+	}
+	if creq.ChatHistory[0].Role != "USER" || creq.ChatHistory[1].Role != "CHATBOT" {
+		t.Errorf("ChatHistory roles = %q, %q, want USER, CHATBOT", creq.ChatHistory[0].Role, creq.ChatHistory[1].Role)
+	}
+}
+
+func TestToCohereRequestRequiresMessages(t *testing.T) {
+	_, err := toCohereRequest(openai.ChatCompletionRequest{Model: "command-r"})
+	if err == nil {
+		t.Error("expected an error for an empty Messages slice, got nil")
+	}
+}
+
+func TestFromCohereResponsePrefersResponseModel(t *testing.T) {
+	resp := fromCohereResponse(cohereResponse{Text: "hi there", Model: "command-r-plus"}, "command-r")
+
+	if resp.Model != "command-r-plus" {
+		t.Errorf("Model = %q, want %q", resp.Model, "command-r-plus")
+	}
+	if len(resp.Choices) != 1 || resp.Choices[0].Message.Content != "hi there" {
+		t.Errorf("Choices = %+v, want a single choice with content %q", resp.Choices, "hi there")
+	}
+}
+
+func TestCohereRole(t *testing.T) {
+	if got := cohereRole("assistant"); got != "CHATBOT" {
+		t.Errorf("cohereRole(%q) = %q, want %q", "assistant", got, "CHATBOT")
+	}
+	if got := cohereRole("user"); got != "USER" {
+		t.Errorf("cohereRole(%q) = %q, want %q", "user", got, "USER")
+	}
+}
@@ -0,0 +1,10 @@
+package backends
+
+import "fmt"
+
+// errStreamingNotSupported builds the error a backend's ChatCompletionStream
+// returns when it has no streaming support yet, so callers fail fast instead
+// of silently blocking on a method that was never going to produce chunks.
+func errStreamingNotSupported(provider string) error {
+	return fmt.Errorf("%s: streaming is not yet supported", provider)
+}
@@ -0,0 +1,514 @@
+// Package chat provides a conversation-tracking layer on top of an
+// openai.Backend, so callers can send turns by conversation ID instead of
+// re-assembling message history themselves.
+package chat
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	openai "github.com/jeanhaley32/go-openai-client"
+	"github.com/jeanhaley32/go-openai-client/jsonschema"
+	"github.com/jeanhaley32/go-openai-client/tokenizer"
+)
+
+// DefaultMaxToolIterations bounds how many tool-call round trips
+// SendMessageWithTools will make before giving up, in case a misbehaving
+// model or handler keeps requesting more tool calls forever.
+const DefaultMaxToolIterations = 8
+
+// ToolHandler executes a tool call's arguments and returns a result to send
+// back to the model as a role="tool" message.
+type ToolHandler func(ctx context.Context, arguments json.RawMessage) (any, error)
+
+type registeredTool struct {
+	definition openai.Tool
+	handler    ToolHandler
+}
+
+// ControllerConfig configures a Controller's default request parameters.
+type ControllerConfig struct {
+	DefaultModel string
+	MaxTokens    int
+	Temperature  float64
+
+	// ContextWindow caps prompt tokens + MaxTokens before SendMessage
+	// dispatches to the backend. Zero disables trimming. Enforcing it
+	// requires Tokenizer to be set.
+	ContextWindow int
+	// TrimStrategy selects how SendMessage brings an over-budget
+	// conversation back under ContextWindow. Zero value behaves as
+	// TrimOldest.
+	TrimStrategy TrimStrategy
+	// Tokenizer counts tokens for ContextWindow enforcement, e.g. a
+	// tokenizer.BPETokenizer. Required when ContextWindow > 0.
+	Tokenizer tokenizer.Tokenizer
+}
+
+// TrimStrategy selects how Controller.SendMessage handles a conversation
+// that no longer fits within ControllerConfig.ContextWindow.
+type TrimStrategy string
+
+const (
+	// TrimOldest drops the oldest non-system turns until the budget fits.
+	TrimOldest TrimStrategy = "trim_oldest"
+	// SummarizeOldest replaces the oldest non-system turns with a short
+	// summary message, one at a time, until the budget fits.
+	SummarizeOldest TrimStrategy = "summarize_oldest"
+	// Error returns an error instead of trimming when the budget is exceeded.
+	Error TrimStrategy = "error"
+)
+
+// Conversation is a single, ordered sequence of messages tracked by a Controller.
+type Conversation struct {
+	ID        string
+	Messages  []openai.Message
+	CreatedAt time.Time
+}
+
+// ChatRequest asks the Controller to send a user message within a conversation.
+type ChatRequest struct {
+	ConversationID string
+	Message        string
+}
+
+// ChatResponse is the assistant's reply to a ChatRequest.
+type ChatResponse struct {
+	Message openai.Message
+}
+
+// ConversationSummary reports basic statistics about a conversation.
+type ConversationSummary struct {
+	MessageCount      int
+	UserMessages      int
+	AssistantMessages int
+	EstimatedTokens   int
+}
+
+// Stats reports aggregate statistics across every conversation a Controller manages.
+type Stats struct {
+	TotalMessages      int
+	TotalConversations int
+	BackendName        string
+}
+
+// Controller manages conversations against a single openai.Backend.
+type Controller struct {
+	backend openai.Backend
+	config  *ControllerConfig
+
+	mu            sync.Mutex
+	conversations map[string]*Conversation
+	nextID        int
+	tools         map[string]registeredTool
+}
+
+// NewController creates a Controller backed by the given Backend.
+func NewController(backend openai.Backend, config *ControllerConfig) *Controller {
+	if config == nil {
+		config = &ControllerConfig{}
+	}
+	return &Controller{
+		backend:       backend,
+		config:        config,
+		conversations: make(map[string]*Conversation),
+	}
+}
+
+// SetBackend swaps the Backend a Controller sends requests to, e.g. to move
+// from a Config-selected provider at startup to one resolved at runtime.
+// Conversations and registered tools are unaffected.
+func (c *Controller) SetBackend(backend openai.Backend) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.backend = backend
+}
+
+// CreateConversation starts a new conversation, optionally seeded with a system prompt.
+func (c *Controller) CreateConversation(systemPrompt string) *Conversation {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.nextID++
+	conv := &Conversation{
+		ID:        fmt.Sprintf("conv-%d", c.nextID),
+		CreatedAt: time.Now(),
+	}
+	if systemPrompt != "" {
+		conv.Messages = append(conv.Messages, openai.Message{Role: "system", Content: systemPrompt})
+	}
+	c.conversations[conv.ID] = conv
+	return conv
+}
+
+func (c *Controller) conversation(id string) (*Conversation, error) {
+	conv, ok := c.conversations[id]
+	if !ok {
+		return nil, fmt.Errorf("conversation %q not found", id)
+	}
+	return conv, nil
+}
+
+// buildRequest assembles a ChatCompletionRequest from messages and the
+// Controller's default parameters. includeTools attaches every RegisterTool
+// definition so the model may request tool calls; only SendMessageWithTools
+// sets it, since it's the only caller that dispatches the resulting
+// tool_calls and appends the required role="tool" follow-ups. SendMessage
+// and StreamMessage leave it false so a model never returns unresolved
+// tool_calls those paths can't satisfy.
+func (c *Controller) buildRequest(messages []openai.Message, includeTools bool) openai.ChatCompletionRequest {
+	req := openai.ChatCompletionRequest{
+		Model:    c.config.DefaultModel,
+		Messages: messages,
+	}
+	if c.config.MaxTokens > 0 {
+		maxTokens := c.config.MaxTokens
+		req.MaxTokens = &maxTokens
+	}
+	if c.config.Temperature > 0 {
+		temperature := c.config.Temperature
+		req.Temperature = &temperature
+	}
+	if includeTools {
+		for _, tool := range c.tools {
+			req.Tools = append(req.Tools, tool.definition)
+		}
+	}
+	return req
+}
+
+// RegisterTool makes a function available for the model to call via
+// SendMessageWithTools. Registering a tool with a name that's already
+// registered replaces it.
+func (c *Controller) RegisterTool(name, description string, schema jsonschema.Definition, handler ToolHandler) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.tools == nil {
+		c.tools = make(map[string]registeredTool)
+	}
+	c.tools[name] = registeredTool{
+		definition: openai.Tool{
+			Type: "function",
+			Function: openai.ToolFunction{
+				Name:        name,
+				Description: description,
+				Parameters:  schema,
+			},
+		},
+		handler: handler,
+	}
+}
+
+// SendMessage appends the user's message to the conversation, sends the full
+// history to the backend, and appends the assistant's reply in turn.
+func (c *Controller) SendMessage(ctx context.Context, req ChatRequest) (*ChatResponse, error) {
+	c.mu.Lock()
+	conv, err := c.conversation(req.ConversationID)
+	if err != nil {
+		c.mu.Unlock()
+		return nil, err
+	}
+	conv.Messages = append(conv.Messages, openai.Message{Role: "user", Content: req.Message})
+	if err := c.fitContextWindow(conv); err != nil {
+		c.mu.Unlock()
+		return nil, err
+	}
+	messages := append([]openai.Message(nil), conv.Messages...)
+	chatReq := c.buildRequest(messages, false)
+	backend := c.backend
+	c.mu.Unlock()
+
+	resp, err := backend.ChatCompletion(ctx, chatReq)
+	if err != nil {
+		return nil, fmt.Errorf("chat completion failed: %w", err)
+	}
+	if len(resp.Choices) == 0 {
+		return nil, fmt.Errorf("no response choices returned")
+	}
+
+	reply := resp.Choices[0].Message
+
+	c.mu.Lock()
+	conv.Messages = append(conv.Messages, reply)
+	c.mu.Unlock()
+
+	return &ChatResponse{Message: reply}, nil
+}
+
+// fitContextWindow trims conv.Messages, per config.TrimStrategy, until
+// prompt tokens plus config.MaxTokens fit within config.ContextWindow.
+// The caller must hold c.mu. It's a no-op when ContextWindow or Tokenizer
+// isn't configured.
+func (c *Controller) fitContextWindow(conv *Conversation) error {
+	if c.config.ContextWindow <= 0 || c.config.Tokenizer == nil {
+		return nil
+	}
+
+	for {
+		promptTokens := c.config.Tokenizer.CountMessages(conv.Messages, c.config.DefaultModel)
+		if promptTokens+c.config.MaxTokens <= c.config.ContextWindow {
+			return nil
+		}
+
+		oldest := oldestTrimmableIndex(conv.Messages)
+		if oldest == -1 {
+			return fmt.Errorf("conversation exceeds context window (%d tokens) even after trimming", c.config.ContextWindow)
+		}
+
+		if c.config.TrimStrategy == Error {
+			return fmt.Errorf("conversation exceeds context window (%d tokens)", c.config.ContextWindow)
+		}
+
+		if c.config.TrimStrategy == SummarizeOldest && conv.Messages[oldest].Role != "system" {
+			conv.Messages[oldest] = openai.Message{
+				Role:    "system",
+				Content: fmt.Sprintf("[earlier message summarized: %.60q]", conv.Messages[oldest].Content),
+			}
+			continue
+		}
+
+		conv.Messages = append(conv.Messages[:oldest], conv.Messages[oldest+1:]...)
+	}
+}
+
+// oldestTrimmableIndex returns the index of the oldest non-system message,
+// or -1 if every remaining message is a system prompt.
+func oldestTrimmableIndex(messages []openai.Message) int {
+	for i, msg := range messages {
+		if msg.Role != "system" {
+			return i
+		}
+	}
+	return -1
+}
+
+// CountTokens reports how many tokens conversationID's current messages
+// would cost under config.DefaultModel, using config.Tokenizer. It returns 0
+// without error if no Tokenizer is configured.
+func (c *Controller) CountTokens(conversationID string) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	conv, err := c.conversation(conversationID)
+	if err != nil {
+		return 0, err
+	}
+	if c.config.Tokenizer == nil {
+		return 0, nil
+	}
+
+	return c.config.Tokenizer.CountMessages(conv.Messages, c.config.DefaultModel), nil
+}
+
+// SendMessageWithTools behaves like SendMessage, but loops: whenever the
+// model's reply requests tool calls, it dispatches each to the matching
+// RegisterTool handler, appends the results as role="tool" messages, and
+// re-invokes the model, stopping once a reply carries no more tool calls or
+// maxIterations round trips have happened. maxIterations <= 0 uses
+// DefaultMaxToolIterations.
+func (c *Controller) SendMessageWithTools(ctx context.Context, req ChatRequest, maxIterations int) (*ChatResponse, error) {
+	if maxIterations <= 0 {
+		maxIterations = DefaultMaxToolIterations
+	}
+
+	c.mu.Lock()
+	conv, err := c.conversation(req.ConversationID)
+	if err != nil {
+		c.mu.Unlock()
+		return nil, err
+	}
+	conv.Messages = append(conv.Messages, openai.Message{Role: "user", Content: req.Message})
+	c.mu.Unlock()
+
+	for i := 0; i < maxIterations; i++ {
+		c.mu.Lock()
+		if err := c.fitContextWindow(conv); err != nil {
+			c.mu.Unlock()
+			return nil, err
+		}
+		messages := append([]openai.Message(nil), conv.Messages...)
+		chatReq := c.buildRequest(messages, true)
+		backend := c.backend
+		c.mu.Unlock()
+
+		resp, err := backend.ChatCompletion(ctx, chatReq)
+		if err != nil {
+			return nil, fmt.Errorf("chat completion failed: %w", err)
+		}
+		if len(resp.Choices) == 0 {
+			return nil, fmt.Errorf("no response choices returned")
+		}
+
+		reply := resp.Choices[0].Message
+
+		c.mu.Lock()
+		conv.Messages = append(conv.Messages, reply)
+		c.mu.Unlock()
+
+		if len(reply.ToolCalls) == 0 {
+			return &ChatResponse{Message: reply}, nil
+		}
+
+		for _, call := range reply.ToolCalls {
+			result, err := c.dispatchTool(ctx, call)
+			if err != nil {
+				result = fmt.Sprintf("error: %v", err)
+			}
+
+			resultContent, err := json.Marshal(result)
+			if err != nil {
+				resultContent = []byte(fmt.Sprintf("%v", result))
+			}
+
+			c.mu.Lock()
+			conv.Messages = append(conv.Messages, openai.Message{
+				Role:       "tool",
+				Content:    string(resultContent),
+				ToolCallID: call.ID,
+				Name:       call.Function.Name,
+			})
+			c.mu.Unlock()
+		}
+	}
+
+	return nil, fmt.Errorf("exceeded max tool iterations (%d) without a final reply", maxIterations)
+}
+
+func (c *Controller) dispatchTool(ctx context.Context, call openai.ToolCall) (any, error) {
+	c.mu.Lock()
+	tool, ok := c.tools[call.Function.Name]
+	c.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("no tool registered with name %q", call.Function.Name)
+	}
+
+	return tool.handler(ctx, json.RawMessage(call.Function.Arguments))
+}
+
+// StreamMessage behaves like SendMessage, but returns chunks as they arrive
+// instead of waiting for the full reply. The returned channel is closed once
+// the stream ends; the assistant's full reply is appended to the
+// conversation only after it has been completely received. Errors
+// encountered mid-stream are sent on errCh and end the stream early.
+func (c *Controller) StreamMessage(ctx context.Context, req ChatRequest) (<-chan openai.ChatCompletionChunk, <-chan error, error) {
+	c.mu.Lock()
+	conv, err := c.conversation(req.ConversationID)
+	if err != nil {
+		c.mu.Unlock()
+		return nil, nil, err
+	}
+	conv.Messages = append(conv.Messages, openai.Message{Role: "user", Content: req.Message})
+	if err := c.fitContextWindow(conv); err != nil {
+		c.mu.Unlock()
+		return nil, nil, err
+	}
+	messages := append([]openai.Message(nil), conv.Messages...)
+	chatReq := c.buildRequest(messages, false)
+	backend := c.backend
+	c.mu.Unlock()
+
+	stream, err := backend.ChatCompletionStream(ctx, chatReq)
+	if err != nil {
+		return nil, nil, fmt.Errorf("chat completion stream failed: %w", err)
+	}
+
+	chunkCh := make(chan openai.ChatCompletionChunk)
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer close(chunkCh)
+		defer close(errCh)
+		defer stream.Close()
+
+		var content string
+		for {
+			chunk, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				errCh <- fmt.Errorf("stream recv failed: %w", err)
+				return
+			}
+			if len(chunk.Choices) > 0 {
+				content += chunk.Choices[0].Delta.Content
+			}
+
+			select {
+			case chunkCh <- chunk:
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		c.mu.Lock()
+		conv.Messages = append(conv.Messages, openai.Message{Role: "assistant", Content: content})
+		c.mu.Unlock()
+	}()
+
+	return chunkCh, errCh, nil
+}
+
+// GetConversationSummary reports basic statistics about a conversation.
+func (c *Controller) GetConversationSummary(conversationID string) (*ConversationSummary, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	conv, err := c.conversation(conversationID)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &ConversationSummary{}
+	for _, msg := range conv.Messages {
+		summary.MessageCount++
+		switch msg.Role {
+		case "user":
+			summary.UserMessages++
+		case "assistant":
+			summary.AssistantMessages++
+		}
+	}
+
+	if c.config.Tokenizer != nil {
+		summary.EstimatedTokens = c.config.Tokenizer.CountMessages(conv.Messages, c.config.DefaultModel)
+	} else {
+		for _, msg := range conv.Messages {
+			summary.EstimatedTokens += len(msg.Content) / 4
+		}
+	}
+
+	return summary, nil
+}
+
+// ListConversations returns every conversation this Controller currently manages.
+func (c *Controller) ListConversations() []*Conversation {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	conversations := make([]*Conversation, 0, len(c.conversations))
+	for _, conv := range c.conversations {
+		conversations = append(conversations, conv)
+	}
+	return conversations
+}
+
+// GetStats reports aggregate statistics across every conversation this Controller manages.
+func (c *Controller) GetStats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := Stats{
+		TotalConversations: len(c.conversations),
+		BackendName:        c.backend.Name(),
+	}
+	for _, conv := range c.conversations {
+		stats.TotalMessages += len(conv.Messages)
+	}
+	return stats
+}
@@ -0,0 +1,203 @@
+package chat
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	openai "github.com/jeanhaley32/go-openai-client"
+	"github.com/jeanhaley32/go-openai-client/jsonschema"
+)
+
+// toolRecordingBackend wraps a MockBackend and records whether the most
+// recent ChatCompletion request carried any tool definitions, so tests can
+// assert on whether a code path attaches tools without needing a live model.
+type toolRecordingBackend struct {
+	*openai.MockBackend
+	lastRequestHadTools bool
+}
+
+func (b *toolRecordingBackend) ChatCompletion(ctx context.Context, req openai.ChatCompletionRequest) (*openai.ChatCompletionResponse, error) {
+	b.lastRequestHadTools = len(req.Tools) > 0
+	return b.MockBackend.ChatCompletion(ctx, req)
+}
+
+func echoSchema() jsonschema.Definition {
+	return jsonschema.Definition{
+		Type: jsonschema.Object,
+		Properties: map[string]jsonschema.Definition{
+			"text": {Type: jsonschema.String},
+		},
+		Required: []string{"text"},
+	}
+}
+
+func TestSendMessageDoesNotAttachTools(t *testing.T) {
+	backend := &toolRecordingBackend{MockBackend: openai.NewMockBackend()}
+	controller := NewController(backend, &ControllerConfig{DefaultModel: "gpt-4"})
+	controller.RegisterTool("echo", "echoes its input", echoSchema(), func(ctx context.Context, args json.RawMessage) (any, error) {
+		return "unused", nil
+	})
+
+	conv := controller.CreateConversation("")
+	if _, err := controller.SendMessage(context.Background(), ChatRequest{ConversationID: conv.ID, Message: "hi"}); err != nil {
+		t.Fatalf("SendMessage returned error: %v", err)
+	}
+
+	if backend.lastRequestHadTools {
+		t.Error("SendMessage attached tools to the request, want none")
+	}
+}
+
+func TestSendMessageWithToolsDispatchesRegisteredHandler(t *testing.T) {
+	backend := &toolRecordingBackend{MockBackend: openai.NewMockBackend()}
+	backend.ScriptToolCall("echo", `{"text":"hello"}`)
+
+	var gotArgs string
+	controller := NewController(backend, &ControllerConfig{DefaultModel: "gpt-4"})
+	controller.RegisterTool("echo", "echoes its input", echoSchema(), func(ctx context.Context, args json.RawMessage) (any, error) {
+		var parsed struct {
+			Text string `json:"text"`
+		}
+		if err := json.Unmarshal(args, &parsed); err != nil {
+			return nil, err
+		}
+		gotArgs = parsed.Text
+		return map[string]string{"echoed": parsed.Text}, nil
+	})
+
+	conv := controller.CreateConversation("")
+	resp, err := controller.SendMessageWithTools(context.Background(), ChatRequest{ConversationID: conv.ID, Message: "say hello"}, 0)
+	if err != nil {
+		t.Fatalf("SendMessageWithTools returned error: %v", err)
+	}
+
+	if !backend.lastRequestHadTools {
+		t.Error("SendMessageWithTools did not attach tools to the request")
+	}
+	if gotArgs != "hello" {
+		t.Errorf("tool handler received args %q, want %q", gotArgs, "hello")
+	}
+	if resp.Message.Role != "assistant" {
+		t.Errorf("final reply role = %q, want %q", resp.Message.Role, "assistant")
+	}
+
+	// The conversation must carry a role="tool" reply immediately after the
+	// assistant's tool_calls message, or a real backend would reject the
+	// next request with a 400.
+	var sawToolCallsMessage bool
+	for _, msg := range conv.Messages {
+		if sawToolCallsMessage {
+			if msg.Role != "tool" {
+				t.Errorf("message after tool_calls has role %q, want %q", msg.Role, "tool")
+			}
+			sawToolCallsMessage = false
+			continue
+		}
+		if len(msg.ToolCalls) > 0 {
+			sawToolCallsMessage = true
+		}
+	}
+}
+
+func TestSendMessageWithToolsStopsAtMaxIterations(t *testing.T) {
+	backend := openai.NewMockBackend()
+	for i := 0; i < 5; i++ {
+		backend.ScriptToolCall("echo", `{"text":"again"}`)
+	}
+
+	controller := NewController(backend, &ControllerConfig{DefaultModel: "gpt-4"})
+	controller.RegisterTool("echo", "echoes its input", echoSchema(), func(ctx context.Context, args json.RawMessage) (any, error) {
+		return "ok", nil
+	})
+
+	conv := controller.CreateConversation("")
+	_, err := controller.SendMessageWithTools(context.Background(), ChatRequest{ConversationID: conv.ID, Message: "loop"}, 2)
+	if err == nil {
+		t.Fatal("expected an error once maxIterations is exceeded, got nil")
+	}
+}
+
+// countingTokenizer is a stub tokenizer.Tokenizer that counts one token per
+// message, so tests can tell whether a code path actually delegates to the
+// configured Tokenizer instead of falling back to len(Content)/4.
+type countingTokenizer struct{}
+
+func (countingTokenizer) CountMessages(messages []openai.Message, model string) int {
+	return len(messages)
+}
+
+func TestGetConversationSummaryUsesConfiguredTokenizer(t *testing.T) {
+	backend := openai.NewMockBackend()
+	controller := NewController(backend, &ControllerConfig{DefaultModel: "gpt-4", Tokenizer: countingTokenizer{}})
+
+	conv := controller.CreateConversation("you are a test")
+	if _, err := controller.SendMessage(context.Background(), ChatRequest{ConversationID: conv.ID, Message: "hi"}); err != nil {
+		t.Fatalf("SendMessage returned error: %v", err)
+	}
+
+	summary, err := controller.GetConversationSummary(conv.ID)
+	if err != nil {
+		t.Fatalf("GetConversationSummary returned error: %v", err)
+	}
+
+	want := len(conv.Messages)
+	if summary.EstimatedTokens != want {
+		t.Errorf("EstimatedTokens = %d, want %d (one token per message from the stub tokenizer)", summary.EstimatedTokens, want)
+	}
+}
+
+func TestSendMessageWithToolsTrimsContextWindow(t *testing.T) {
+	backend := openai.NewMockBackend()
+	backend.ScriptToolCall("echo", `{"text":"hi"}`)
+
+	// countingTokenizer counts one token per message, so a ContextWindow of 3
+	// forces trimming once the conversation grows past a couple of turns.
+	controller := NewController(backend, &ControllerConfig{
+		DefaultModel:  "gpt-4",
+		ContextWindow: 3,
+		Tokenizer:     countingTokenizer{},
+	})
+	controller.RegisterTool("echo", "echoes its input", echoSchema(), func(ctx context.Context, args json.RawMessage) (any, error) {
+		return "ok", nil
+	})
+
+	conv := controller.CreateConversation("system prompt")
+	if _, err := controller.SendMessageWithTools(context.Background(), ChatRequest{ConversationID: conv.ID, Message: "hello"}, 0); err != nil {
+		t.Fatalf("SendMessageWithTools returned error: %v", err)
+	}
+
+	// The tool-call round trip grows the conversation past ContextWindow
+	// mid-loop; if fitContextWindow only ran in SendMessage, the original
+	// "hello" turn would still be here.
+	for _, msg := range conv.Messages {
+		if msg.Content == "hello" {
+			t.Error("oldest user turn survived the tool-call loop, want it trimmed")
+		}
+	}
+}
+
+func TestSetBackendRedirectsSubsequentRequests(t *testing.T) {
+	first := &toolRecordingBackend{MockBackend: openai.NewMockBackend()}
+	second := &toolRecordingBackend{MockBackend: openai.NewMockBackend()}
+	// Give the second backend a distinct name so the check below can tell
+	// the two apart.
+	if err := second.Configure(map[string]interface{}{"name": "Second Mock"}); err != nil {
+		t.Fatalf("Configure returned error: %v", err)
+	}
+	controller := NewController(first, &ControllerConfig{DefaultModel: "gpt-4"})
+
+	conv := controller.CreateConversation("")
+	if _, err := controller.SendMessage(context.Background(), ChatRequest{ConversationID: conv.ID, Message: "hi"}); err != nil {
+		t.Fatalf("SendMessage returned error: %v", err)
+	}
+
+	controller.SetBackend(second)
+	if _, err := controller.SendMessage(context.Background(), ChatRequest{ConversationID: conv.ID, Message: "hi again"}); err != nil {
+		t.Fatalf("SendMessage returned error: %v", err)
+	}
+
+	if controller.GetStats().BackendName != second.Name() {
+		t.Errorf("BackendName = %q, want %q after SetBackend", controller.GetStats().BackendName, second.Name())
+	}
+}
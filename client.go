@@ -5,8 +5,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
+	"sync"
 	"time"
 )
 
@@ -16,6 +16,13 @@ type Client struct {
 	baseURL    string
 	httpClient *http.Client
 	model      string
+	maxRetries int
+	// sleep is used between retries; overridable via SetSleepFunc so tests
+	// can validate retry behavior without real delays.
+	sleep func(time.Duration)
+
+	rateLimitMu sync.Mutex
+	rateLimit   *RateLimitInfo
 }
 
 // Config holds configuration for the OpenAI client
@@ -25,6 +32,9 @@ type Config struct {
 	Model      string        `json:"model"`
 	Timeout    time.Duration `json:"timeout"`
 	MaxRetries int           `json:"max_retries"`
+	// Provider selects which registered backend NewBackend constructs.
+	// Empty defaults to "openai".
+	Provider string `json:"provider"`
 }
 
 // NewClient creates a new OpenAI client instance
@@ -40,15 +50,32 @@ func NewClient(config Config) *Client {
 	}
 
 	return &Client{
-		apiKey:  config.APIKey,
-		baseURL: config.BaseURL,
-		model:   config.Model,
+		apiKey:     config.APIKey,
+		baseURL:    config.BaseURL,
+		model:      config.Model,
+		maxRetries: config.MaxRetries,
+		sleep:      time.Sleep,
 		httpClient: &http.Client{
 			Timeout: config.Timeout,
 		},
 	}
 }
 
+// SetSleepFunc overrides the function Client sleeps with between retries.
+// Tests that spin up an httptest.NewServer can inject a no-op to validate
+// retry behavior deterministically.
+func (c *Client) SetSleepFunc(sleep func(time.Duration)) {
+	c.sleep = sleep
+}
+
+// LastRateLimit returns the rate-limit info parsed from the most recent
+// successful response's headers, or nil if none has been recorded yet.
+func (c *Client) LastRateLimit() *RateLimitInfo {
+	c.rateLimitMu.Lock()
+	defer c.rateLimitMu.Unlock()
+	return c.rateLimit
+}
+
 // Name returns the name of this backend
 func (c *Client) Name() string {
 	return "OpenAI"
@@ -66,12 +93,14 @@ func (c *Client) ChatCompletion(ctx context.Context, req ChatCompletionRequest)
 
 	// Convert our request to OpenAI's format (they're the same, but we want to be explicit)
 	openAIRequest := struct {
-		Model       string    `json:"model"`
-		Messages    []Message `json:"messages"`
-		MaxTokens   *int      `json:"max_tokens,omitempty"`
-		Temperature *float64  `json:"temperature,omitempty"`
-		TopP        *float64  `json:"top_p,omitempty"`
-		Stream      bool      `json:"stream,omitempty"`
+		Model       string      `json:"model"`
+		Messages    []Message   `json:"messages"`
+		MaxTokens   *int        `json:"max_tokens,omitempty"`
+		Temperature *float64    `json:"temperature,omitempty"`
+		TopP        *float64    `json:"top_p,omitempty"`
+		Stream      bool        `json:"stream,omitempty"`
+		Tools       []Tool      `json:"tools,omitempty"`
+		ToolChoice  interface{} `json:"tool_choice,omitempty"`
 	}{
 		Model:       req.Model,
 		Messages:    req.Messages,
@@ -79,6 +108,8 @@ func (c *Client) ChatCompletion(ctx context.Context, req ChatCompletionRequest)
 		Temperature: req.Temperature,
 		TopP:        req.TopP,
 		Stream:      req.Stream,
+		Tools:       req.Tools,
+		ToolChoice:  req.ToolChoice,
 	}
 
 	// Marshal request to JSON
@@ -87,48 +118,27 @@ func (c *Client) ChatCompletion(ctx context.Context, req ChatCompletionRequest)
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	// Create HTTP request
 	url := fmt.Sprintf("%s/chat/completions", c.baseURL)
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(requestBody))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	// Set headers
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.apiKey))
-
-	// Send request
-	resp, err := c.httpClient.Do(httpReq)
-	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %w", err)
-	}
-	defer resp.Body.Close()
 
-	// Read response body
-	responseBody, err := io.ReadAll(resp.Body)
+	responseHeader, responseBody, err := DoWithRetry(c.httpClient, c.maxRetries, c.sleep, func() (*http.Request, error) {
+		httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(requestBody))
+		if err != nil {
+			return nil, err
+		}
+		httpReq.Header.Set("Content-Type", "application/json")
+		httpReq.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.apiKey))
+		return httpReq, nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return nil, err
 	}
 
-	// Check for HTTP errors
-	if resp.StatusCode != http.StatusOK {
-		var errorResponse struct {
-			Error struct {
-				Message string `json:"message"`
-				Type    string `json:"type"`
-				Code    string `json:"code"`
-			} `json:"error"`
-		}
-
-		if err := json.Unmarshal(responseBody, &errorResponse); err == nil {
-			return nil, fmt.Errorf("OpenAI API error (%d): %s", resp.StatusCode, errorResponse.Error.Message)
-		}
-
-		return nil, fmt.Errorf("OpenAI API error (%d): %s", resp.StatusCode, string(responseBody))
+	if info := parseRateLimitInfo(responseHeader); info != nil {
+		c.rateLimitMu.Lock()
+		c.rateLimit = info
+		c.rateLimitMu.Unlock()
 	}
 
-	// Parse response
 	var openAIResponse ChatCompletionResponse
 	if err := json.Unmarshal(responseBody, &openAIResponse); err != nil {
 		return nil, fmt.Errorf("failed to parse response: %w", err)
@@ -0,0 +1,96 @@
+package openai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClientChatCompletionRetriesOnServerError(t *testing.T) {
+	var attempts int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprint(w, `{"error":{"message":"overloaded"}}`)
+			return
+		}
+		fmt.Fprint(w, `{"id":"1","object":"chat.completion","model":"gpt-4","choices":[{"index":0,"message":{"role":"assistant","content":"hi"},"finish_reason":"stop"}]}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{APIKey: "test", BaseURL: server.URL, MaxRetries: 3})
+	client.SetSleepFunc(func(time.Duration) {})
+
+	resp, err := client.ChatCompletion(context.Background(), ChatCompletionRequest{
+		Model:    "gpt-4",
+		Messages: []Message{{Role: "user", Content: "hi"}},
+	})
+	if err != nil {
+		t.Fatalf("ChatCompletion returned error: %v", err)
+	}
+	if attempts != 3 {
+		t.Errorf("attempts = %d, want 3", attempts)
+	}
+	if resp.Choices[0].Message.Content != "hi" {
+		t.Errorf("content = %q, want %q", resp.Choices[0].Message.Content, "hi")
+	}
+}
+
+func TestClientChatCompletionGivesUpAfterMaxRetries(t *testing.T) {
+	var attempts int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusTooManyRequests)
+		fmt.Fprint(w, `{"error":{"message":"rate limited"}}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{APIKey: "test", BaseURL: server.URL, MaxRetries: 2})
+	client.SetSleepFunc(func(time.Duration) {})
+
+	_, err := client.ChatCompletion(context.Background(), ChatCompletionRequest{
+		Model:    "gpt-4",
+		Messages: []Message{{Role: "user", Content: "hi"}},
+	})
+	if err == nil {
+		t.Fatal("expected an error, got nil")
+	}
+	if attempts != 3 {
+		t.Errorf("attempts = %d, want 3 (1 initial + 2 retries)", attempts)
+	}
+	if !errors.Is(err, ErrRateLimited) {
+		t.Errorf("errors.Is(err, ErrRateLimited) = false, want true")
+	}
+}
+
+func TestClientChatCompletionDoesNotRetryClientError(t *testing.T) {
+	var attempts int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"error":{"message":"bad model","code":"model_not_found"}}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{APIKey: "test", BaseURL: server.URL, MaxRetries: 3})
+	client.SetSleepFunc(func(time.Duration) {})
+
+	_, err := client.ChatCompletion(context.Background(), ChatCompletionRequest{
+		Model:    "gpt-4",
+		Messages: []Message{{Role: "user", Content: "hi"}},
+	})
+	if err == nil {
+		t.Fatal("expected an error, got nil")
+	}
+	if attempts != 1 {
+		t.Errorf("attempts = %d, want 1 (400s aren't retryable)", attempts)
+	}
+	if !errors.Is(err, ErrInvalidModel) {
+		t.Errorf("errors.Is(err, ErrInvalidModel) = false, want true")
+	}
+}
@@ -0,0 +1,303 @@
+package openai
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// CompatibleConfig configures a CompatibleClient.
+type CompatibleConfig struct {
+	Config
+	// AuthHeader is the HTTP header carrying the API key. Defaults to
+	// "Authorization".
+	AuthHeader string
+	// AuthPrefix is prepended to the API key in AuthHeader, e.g. "Bearer ".
+	// Defaults to "Bearer ".
+	AuthPrefix string
+}
+
+// CompatibleClient talks to any server that speaks the OpenAI chat
+// completions API shape but isn't OpenAI itself, e.g. a self-hosted LocalAI,
+// vLLM, or Ollama instance. It differs from Client in allowing a custom auth
+// header name and prefix, and in not supporting Moderations.
+type CompatibleClient struct {
+	apiKey     string
+	baseURL    string
+	model      string
+	authHeader string
+	authPrefix string
+	httpClient *http.Client
+	maxRetries int
+	// sleep is used between retries; overridable via SetSleepFunc so tests
+	// can validate retry behavior without real delays.
+	sleep func(time.Duration)
+
+	rateLimitMu sync.Mutex
+	rateLimit   *RateLimitInfo
+}
+
+// NewCompatibleClient creates a CompatibleClient for a self-hosted or
+// third-party OpenAI-compatible server.
+func NewCompatibleClient(config CompatibleConfig) *CompatibleClient {
+	if config.BaseURL == "" {
+		config.BaseURL = "http://localhost:8080/v1"
+	}
+	if config.Timeout == 0 {
+		config.Timeout = 30 * time.Second
+	}
+	if config.AuthHeader == "" {
+		config.AuthHeader = "Authorization"
+	}
+	if config.AuthPrefix == "" {
+		config.AuthPrefix = "Bearer "
+	}
+
+	return &CompatibleClient{
+		apiKey:     config.APIKey,
+		baseURL:    config.BaseURL,
+		model:      config.Model,
+		authHeader: config.AuthHeader,
+		authPrefix: config.AuthPrefix,
+		maxRetries: config.MaxRetries,
+		sleep:      time.Sleep,
+		httpClient: &http.Client{Timeout: config.Timeout},
+	}
+}
+
+// Name returns the name of this backend.
+func (c *CompatibleClient) Name() string {
+	return "Compatible"
+}
+
+// SetSleepFunc overrides the function CompatibleClient sleeps with between
+// retries. Tests that spin up an httptest.NewServer can inject a no-op to
+// validate retry behavior deterministically.
+func (c *CompatibleClient) SetSleepFunc(sleep func(time.Duration)) {
+	c.sleep = sleep
+}
+
+// LastRateLimit returns the rate-limit info parsed from the most recent
+// successful response's headers, or nil if none has been recorded yet.
+func (c *CompatibleClient) LastRateLimit() *RateLimitInfo {
+	c.rateLimitMu.Lock()
+	defer c.rateLimitMu.Unlock()
+	return c.rateLimit
+}
+
+func (c *CompatibleClient) setAuth(req *http.Request) {
+	if c.apiKey == "" {
+		return
+	}
+	req.Header.Set(c.authHeader, c.authPrefix+c.apiKey)
+}
+
+// ChatCompletion sends a chat completion request to the configured
+// OpenAI-compatible server.
+func (c *CompatibleClient) ChatCompletion(ctx context.Context, req ChatCompletionRequest) (*ChatCompletionResponse, error) {
+	if req.Model == "" {
+		return nil, fmt.Errorf("model is required")
+	}
+	if len(req.Messages) == 0 {
+		return nil, fmt.Errorf("messages are required")
+	}
+
+	requestBody, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/chat/completions", c.baseURL)
+	responseHeader, responseBody, err := DoWithRetry(c.httpClient, c.maxRetries, c.sleep, func() (*http.Request, error) {
+		httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(requestBody))
+		if err != nil {
+			return nil, err
+		}
+		httpReq.Header.Set("Content-Type", "application/json")
+		c.setAuth(httpReq)
+		return httpReq, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if info := parseRateLimitInfo(responseHeader); info != nil {
+		c.rateLimitMu.Lock()
+		c.rateLimit = info
+		c.rateLimitMu.Unlock()
+	}
+
+	var chatResp ChatCompletionResponse
+	if err := json.Unmarshal(responseBody, &chatResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &chatResp, nil
+}
+
+// ChatCompletionStream opens a streamed chat completion request against the
+// configured OpenAI-compatible server.
+func (c *CompatibleClient) ChatCompletionStream(ctx context.Context, req ChatCompletionRequest) (*ChatCompletionStream, error) {
+	if req.Model == "" {
+		return nil, fmt.Errorf("model is required")
+	}
+	if len(req.Messages) == 0 {
+		return nil, fmt.Errorf("messages are required")
+	}
+	req.Stream = true
+
+	requestBody, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	url := fmt.Sprintf("%s/chat/completions", c.baseURL)
+	resp, err := DoStreamWithRetry(c.httpClient, c.maxRetries, c.sleep, func() (*http.Request, error) {
+		httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(requestBody))
+		if err != nil {
+			return nil, err
+		}
+		httpReq.Header.Set("Content-Type", "application/json")
+		httpReq.Header.Set("Accept", "text/event-stream")
+		c.setAuth(httpReq)
+		return httpReq, nil
+	})
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	if info := parseRateLimitInfo(resp.Header); info != nil {
+		c.rateLimitMu.Lock()
+		c.rateLimit = info
+		c.rateLimitMu.Unlock()
+	}
+
+	return &ChatCompletionStream{
+		source: &httpChunkSource{
+			body:    resp.Body,
+			scanner: bufio.NewScanner(resp.Body),
+			cancel:  cancel,
+		},
+	}, nil
+}
+
+// CreateEmbeddings sends an embeddings request to the configured
+// OpenAI-compatible server.
+func (c *CompatibleClient) CreateEmbeddings(ctx context.Context, req EmbeddingRequest) (*EmbeddingResponse, error) {
+	if req.Input == nil {
+		return nil, fmt.Errorf("input is required")
+	}
+
+	requestBody, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/embeddings", c.baseURL)
+	responseHeader, responseBody, err := DoWithRetry(c.httpClient, c.maxRetries, c.sleep, func() (*http.Request, error) {
+		httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(requestBody))
+		if err != nil {
+			return nil, err
+		}
+		httpReq.Header.Set("Content-Type", "application/json")
+		c.setAuth(httpReq)
+		return httpReq, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if info := parseRateLimitInfo(responseHeader); info != nil {
+		c.rateLimitMu.Lock()
+		c.rateLimit = info
+		c.rateLimitMu.Unlock()
+	}
+
+	var embeddingResp EmbeddingResponse
+	if err := json.Unmarshal(responseBody, &embeddingResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &embeddingResp, nil
+}
+
+// Moderations is not supported by generic OpenAI-compatible servers; most
+// self-hosted runtimes (LocalAI, vLLM, Ollama) don't implement /moderations.
+func (c *CompatibleClient) Moderations(ctx context.Context, req ModerationRequest) (*ModerationResponse, error) {
+	return nil, fmt.Errorf("compatible: moderations endpoint is not supported")
+}
+
+// SendMessage implements the legacy interface by converting to ChatCompletion.
+func (c *CompatibleClient) SendMessage(ctx context.Context, req Request) (*Response, error) {
+	chatReq := ChatCompletionRequest{
+		Model:       req.Model,
+		Messages:    req.Messages,
+		MaxTokens:   req.MaxTokens,
+		Temperature: req.Temperature,
+		TopP:        req.TopP,
+	}
+	if chatReq.Model == "" {
+		chatReq.Model = c.model
+	}
+
+	chatResp, err := c.ChatCompletion(ctx, chatReq)
+	if err != nil {
+		return &Response{Error: err}, err
+	}
+	if len(chatResp.Choices) == 0 {
+		return &Response{Error: fmt.Errorf("no response choices returned")}, fmt.Errorf("no response choices returned")
+	}
+
+	return &Response{
+		Content:    chatResp.Choices[0].Message.Content,
+		TokensUsed: chatResp.Usage.TotalTokens,
+		Model:      chatResp.Model,
+		Timestamp:  time.Unix(chatResp.Created, 0),
+	}, nil
+}
+
+// IsAvailable checks if the configured server is reachable.
+func (c *CompatibleClient) IsAvailable(ctx context.Context) bool {
+	url := fmt.Sprintf("%s/models", c.baseURL)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return false
+	}
+	c.setAuth(req)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return false
+	}
+	defer resp.Body.Close()
+
+	return resp.StatusCode == http.StatusOK
+}
+
+// Configure updates the client configuration.
+func (c *CompatibleClient) Configure(config map[string]interface{}) error {
+	if baseURL, ok := config["base_url"].(string); ok && baseURL != "" {
+		c.baseURL = baseURL
+	}
+	if apiKey, ok := config["api_key"].(string); ok && apiKey != "" {
+		c.apiKey = apiKey
+	}
+	if model, ok := config["model"].(string); ok && model != "" {
+		c.model = model
+	}
+	if authHeader, ok := config["auth_header"].(string); ok && authHeader != "" {
+		c.authHeader = authHeader
+	}
+	if authPrefix, ok := config["auth_prefix"].(string); ok {
+		c.authPrefix = authPrefix
+	}
+	return nil
+}
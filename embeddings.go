@@ -0,0 +1,90 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// CreateEmbeddings sends an embeddings request to OpenAI's API.
+func (c *Client) CreateEmbeddings(ctx context.Context, req EmbeddingRequest) (*EmbeddingResponse, error) {
+	if req.Model == "" {
+		return nil, fmt.Errorf("model is required")
+	}
+	if req.Input == nil {
+		return nil, fmt.Errorf("input is required")
+	}
+
+	requestBody, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/embeddings", c.baseURL)
+	responseHeader, responseBody, err := DoWithRetry(c.httpClient, c.maxRetries, c.sleep, func() (*http.Request, error) {
+		httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(requestBody))
+		if err != nil {
+			return nil, err
+		}
+		httpReq.Header.Set("Content-Type", "application/json")
+		httpReq.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.apiKey))
+		return httpReq, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if info := parseRateLimitInfo(responseHeader); info != nil {
+		c.rateLimitMu.Lock()
+		c.rateLimit = info
+		c.rateLimitMu.Unlock()
+	}
+
+	var embeddingResp EmbeddingResponse
+	if err := json.Unmarshal(responseBody, &embeddingResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &embeddingResp, nil
+}
+
+// Moderations sends a moderation request to OpenAI's API.
+func (c *Client) Moderations(ctx context.Context, req ModerationRequest) (*ModerationResponse, error) {
+	if req.Input == nil {
+		return nil, fmt.Errorf("input is required")
+	}
+
+	requestBody, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/moderations", c.baseURL)
+	responseHeader, responseBody, err := DoWithRetry(c.httpClient, c.maxRetries, c.sleep, func() (*http.Request, error) {
+		httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(requestBody))
+		if err != nil {
+			return nil, err
+		}
+		httpReq.Header.Set("Content-Type", "application/json")
+		httpReq.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.apiKey))
+		return httpReq, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if info := parseRateLimitInfo(responseHeader); info != nil {
+		c.rateLimitMu.Lock()
+		c.rateLimit = info
+		c.rateLimitMu.Unlock()
+	}
+
+	var moderationResp ModerationResponse
+	if err := json.Unmarshal(responseBody, &moderationResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &moderationResp, nil
+}
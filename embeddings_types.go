@@ -0,0 +1,69 @@
+package openai
+
+// EmbeddingRequest mirrors OpenAI's /v1/embeddings request body. Input may
+// be a single string or a []string.
+type EmbeddingRequest struct {
+	Input          interface{} `json:"input"`
+	Model          string      `json:"model"`
+	EncodingFormat string      `json:"encoding_format,omitempty"`
+}
+
+// EmbeddingData is a single input's embedding vector.
+type EmbeddingData struct {
+	Index     int       `json:"index"`
+	Embedding []float64 `json:"embedding"`
+	Object    string    `json:"object"`
+}
+
+// EmbeddingResponse mirrors OpenAI's /v1/embeddings response body.
+type EmbeddingResponse struct {
+	Object string          `json:"object"`
+	Data   []EmbeddingData `json:"data"`
+	Model  string          `json:"model"`
+	Usage  Usage           `json:"usage"`
+}
+
+// ModerationRequest mirrors OpenAI's /v1/moderations request body. Input may
+// be a single string or a []string.
+type ModerationRequest struct {
+	Input interface{} `json:"input"`
+	Model string      `json:"model,omitempty"`
+}
+
+// ModerationCategories flags which moderation categories a moderated input
+// tripped.
+type ModerationCategories struct {
+	Sexual          bool `json:"sexual"`
+	Hate            bool `json:"hate"`
+	Harassment      bool `json:"harassment"`
+	SelfHarm        bool `json:"self-harm"`
+	Violence        bool `json:"violence"`
+	SexualMinors    bool `json:"sexual/minors"`
+	HateThreatening bool `json:"hate/threatening"`
+}
+
+// ModerationCategoryScores carries the raw per-category scores backing
+// ModerationCategories' flags.
+type ModerationCategoryScores struct {
+	Sexual          float64 `json:"sexual"`
+	Hate            float64 `json:"hate"`
+	Harassment      float64 `json:"harassment"`
+	SelfHarm        float64 `json:"self-harm"`
+	Violence        float64 `json:"violence"`
+	SexualMinors    float64 `json:"sexual/minors"`
+	HateThreatening float64 `json:"hate/threatening"`
+}
+
+// ModerationResult is the moderation verdict for a single input.
+type ModerationResult struct {
+	Flagged        bool                     `json:"flagged"`
+	Categories     ModerationCategories     `json:"categories"`
+	CategoryScores ModerationCategoryScores `json:"category_scores"`
+}
+
+// ModerationResponse mirrors OpenAI's /v1/moderations response body.
+type ModerationResponse struct {
+	ID      string             `json:"id"`
+	Model   string             `json:"model"`
+	Results []ModerationResult `json:"results"`
+}
@@ -0,0 +1,292 @@
+package openai
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// APIError represents a non-2xx response from the OpenAI API.
+type APIError struct {
+	StatusCode int
+	Code       string
+	Type       string
+	Message    string
+	Param      string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("openai API error (%d): %s", e.StatusCode, e.Message)
+}
+
+// Is reports whether target is one of the sentinel errors below and matches
+// this APIError's status code and API-reported code/type, so callers can use
+// errors.Is(err, openai.ErrRateLimited) instead of inspecting StatusCode.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrInvalidModel:
+		return e.Code == "model_not_found" || (e.Type == "invalid_request_error" && e.Param == "model")
+	case ErrContextLengthExceeded:
+		return e.Code == "context_length_exceeded"
+	case ErrAuthentication:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrServerError:
+		return e.StatusCode >= 500
+	}
+	return false
+}
+
+// Sentinel errors matched against via errors.Is(err, openai.ErrXxx). Use
+// errors.As(err, &apiErr) to recover the full APIError.
+var (
+	ErrInvalidModel          = errors.New("openai: invalid model")
+	ErrContextLengthExceeded = errors.New("openai: context length exceeded")
+	ErrAuthentication        = errors.New("openai: authentication failed")
+	ErrRateLimited           = errors.New("openai: rate limited")
+	ErrServerError           = errors.New("openai: server error")
+)
+
+// RateLimitInfo reports the rate-limit headers OpenAI attached to the most
+// recent response.
+type RateLimitInfo struct {
+	LimitRequests     int
+	RemainingRequests int
+	ResetRequests     time.Duration
+	LimitTokens       int
+	RemainingTokens   int
+	ResetTokens       time.Duration
+}
+
+func parseRateLimitInfo(header http.Header) *RateLimitInfo {
+	info := &RateLimitInfo{
+		LimitRequests:     parseRateLimitInt(header, "X-Ratelimit-Limit-Requests"),
+		RemainingRequests: parseRateLimitInt(header, "X-Ratelimit-Remaining-Requests"),
+		ResetRequests:     parseRateLimitDuration(header, "X-Ratelimit-Reset-Requests"),
+		LimitTokens:       parseRateLimitInt(header, "X-Ratelimit-Limit-Tokens"),
+		RemainingTokens:   parseRateLimitInt(header, "X-Ratelimit-Remaining-Tokens"),
+		ResetTokens:       parseRateLimitDuration(header, "X-Ratelimit-Reset-Tokens"),
+	}
+
+	if info.LimitRequests == 0 && info.RemainingRequests == 0 && info.LimitTokens == 0 && info.RemainingTokens == 0 {
+		return nil
+	}
+	return info
+}
+
+func parseRateLimitInt(header http.Header, key string) int {
+	value, err := strconv.Atoi(header.Get(key))
+	if err != nil {
+		return 0
+	}
+	return value
+}
+
+// parseRateLimitDuration parses OpenAI's reset headers, which are durations
+// like "1s" or "6m0s" rather than absolute timestamps.
+func parseRateLimitDuration(header http.Header, key string) time.Duration {
+	value, err := time.ParseDuration(header.Get(key))
+	if err != nil {
+		return 0
+	}
+	return value
+}
+
+// parseRetryAfter parses a Retry-After header, which OpenAI sends as a
+// number of seconds.
+func parseRetryAfter(header http.Header) (time.Duration, bool) {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	seconds, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, false
+	}
+	return time.Duration(seconds) * time.Second, true
+}
+
+// isRetryableStatus reports whether an HTTP status code is worth retrying:
+// 429 (rate limited) or any 5xx (server error).
+func isRetryableStatus(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode >= 500
+}
+
+// backoffDuration returns the exponential-backoff-with-jitter delay to wait
+// before retry attempt n (0-indexed), doubling a 500ms base each attempt and
+// capping at 30s.
+func backoffDuration(attempt int) time.Duration {
+	base := 500 * time.Millisecond
+	maxDelay := 30 * time.Second
+
+	delay := base << attempt
+	if delay <= 0 || delay > maxDelay {
+		delay = maxDelay
+	}
+
+	jitter := time.Duration(rand.Int63n(int64(delay) / 2))
+	return delay/2 + jitter
+}
+
+// retryableAPIError wraps APIError with the Retry-After delay the server
+// asked for, so a retry loop can honor it instead of computing its own
+// exponential backoff.
+type retryableAPIError struct {
+	*APIError
+	retryAfter time.Duration
+}
+
+// Unwrap exposes the underlying *APIError so callers can use
+// errors.As(err, &apiErr) to recover it.
+func (r *retryableAPIError) Unwrap() error {
+	return r.APIError
+}
+
+func retryAfterDelay(err error) (time.Duration, bool) {
+	retryable, ok := err.(*retryableAPIError)
+	if !ok || retryable.retryAfter == 0 {
+		return 0, false
+	}
+	return retryable.retryAfter, true
+}
+
+// parseAPIError builds a retryableAPIError from a non-2xx response body,
+// falling back to the raw body as the message when it isn't OpenAI's
+// documented {"error": {...}} shape.
+func parseAPIError(statusCode int, body []byte) *retryableAPIError {
+	var errorResponse struct {
+		Error struct {
+			Message string `json:"message"`
+			Type    string `json:"type"`
+			Code    string `json:"code"`
+			Param   string `json:"param"`
+		} `json:"error"`
+	}
+
+	apiErr := &APIError{StatusCode: statusCode, Message: string(body)}
+	if err := json.Unmarshal(body, &errorResponse); err == nil && errorResponse.Error.Message != "" {
+		apiErr.Message = errorResponse.Error.Message
+		apiErr.Type = errorResponse.Error.Type
+		apiErr.Code = errorResponse.Error.Code
+		apiErr.Param = errorResponse.Error.Param
+	}
+
+	return &retryableAPIError{APIError: apiErr}
+}
+
+// DoWithRetry issues buildReq() via httpClient up to maxRetries+1 times,
+// retrying 429/5xx responses with exponential backoff (or the server's
+// Retry-After, when present) between attempts. sleep defaults to time.Sleep
+// when nil. On a non-retryable or exhausted-retries error status, it returns
+// an error wrapping *APIError (recoverable via errors.As); on success it
+// returns the response headers and body for the caller to unmarshal.
+//
+// DoWithRetry is exported so other packages implementing openai.Backend
+// (e.g. the backends package) can share the same retry/backoff/typed-error
+// behavior as Client and CompatibleClient instead of reimplementing it.
+func DoWithRetry(httpClient *http.Client, maxRetries int, sleep func(time.Duration), buildReq func() (*http.Request, error)) (http.Header, []byte, error) {
+	if sleep == nil {
+		sleep = time.Sleep
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		if attempt > 0 {
+			delay := backoffDuration(attempt - 1)
+			if retryAfter, ok := retryAfterDelay(lastErr); ok {
+				delay = retryAfter
+			}
+			sleep(delay)
+		}
+
+		httpReq, err := buildReq()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create request: %w", err)
+		}
+
+		resp, err := httpClient.Do(httpReq)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to send request: %w", err)
+		}
+
+		responseBody, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read response: %w", err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			apiErr := parseAPIError(resp.StatusCode, responseBody)
+			if retryAfter, hasRetryAfter := parseRetryAfter(resp.Header); hasRetryAfter {
+				apiErr.retryAfter = retryAfter
+			}
+			lastErr = apiErr
+
+			if isRetryableStatus(resp.StatusCode) && attempt < maxRetries {
+				continue
+			}
+			return nil, nil, apiErr
+		}
+
+		return resp.Header, responseBody, nil
+	}
+
+	return nil, nil, lastErr
+}
+
+// DoStreamWithRetry issues buildReq() via httpClient up to maxRetries+1
+// times, retrying 429/5xx responses the same way DoWithRetry does. Unlike
+// DoWithRetry, a successful response's body is left open and unread so the
+// caller can stream it.
+func DoStreamWithRetry(httpClient *http.Client, maxRetries int, sleep func(time.Duration), buildReq func() (*http.Request, error)) (*http.Response, error) {
+	if sleep == nil {
+		sleep = time.Sleep
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		if attempt > 0 {
+			delay := backoffDuration(attempt - 1)
+			if retryAfter, ok := retryAfterDelay(lastErr); ok {
+				delay = retryAfter
+			}
+			sleep(delay)
+		}
+
+		httpReq, err := buildReq()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+
+		resp, err := httpClient.Do(httpReq)
+		if err != nil {
+			return nil, fmt.Errorf("failed to send request: %w", err)
+		}
+
+		if resp.StatusCode == http.StatusOK {
+			return resp, nil
+		}
+
+		responseBody, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		apiErr := parseAPIError(resp.StatusCode, responseBody)
+		if retryAfter, hasRetryAfter := parseRetryAfter(resp.Header); hasRetryAfter {
+			apiErr.retryAfter = retryAfter
+		}
+		lastErr = apiErr
+
+		if isRetryableStatus(resp.StatusCode) && attempt < maxRetries {
+			continue
+		}
+		return nil, apiErr
+	}
+
+	return nil, lastErr
+}
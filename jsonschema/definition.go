@@ -0,0 +1,29 @@
+// Package jsonschema provides a minimal representation of the JSON Schema
+// subset that chat completion providers accept for function/tool call
+// argument validation.
+package jsonschema
+
+// DataType is one of the JSON Schema primitive types this package supports.
+type DataType string
+
+const (
+	Object  DataType = "object"
+	Array   DataType = "array"
+	String  DataType = "string"
+	Number  DataType = "number"
+	Integer DataType = "integer"
+	Boolean DataType = "boolean"
+	Null    DataType = "null"
+)
+
+// Definition describes a JSON Schema document (or subschema). It marshals to
+// a valid JSON Schema subset, enough to constrain a model's tool call
+// arguments without pulling in a full schema library.
+type Definition struct {
+	Type        DataType              `json:"type,omitempty"`
+	Description string                `json:"description,omitempty"`
+	Enum        []string              `json:"enum,omitempty"`
+	Properties  map[string]Definition `json:"properties,omitempty"`
+	Required    []string              `json:"required,omitempty"`
+	Items       *Definition           `json:"items,omitempty"`
+}
@@ -0,0 +1,288 @@
+package openai
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"time"
+)
+
+// MockBackend is an in-memory Backend implementation for examples and tests
+// that don't have (or want) a live API key.
+type MockBackend struct {
+	name        string
+	available   bool
+	responses   []string
+	calls       int
+	StreamDelay time.Duration
+
+	toolCalls []ScriptedToolCall
+
+	// EmbeddingDimensions sizes the fake vectors CreateEmbeddings returns.
+	// Defaults to 8 when zero.
+	EmbeddingDimensions int
+	// ModerationFlagged, when true, makes Moderations flag every category
+	// for every input instead of returning a clean result.
+	ModerationFlagged bool
+}
+
+// ScriptedToolCall is a canned tool call MockBackend returns from
+// ChatCompletion before falling back to its normal text replies, letting
+// callers exercise a chat.Controller tool-calling loop without a live model.
+type ScriptedToolCall struct {
+	Name      string
+	Arguments string
+}
+
+// ScriptToolCall queues a tool call for the next ChatCompletion call to
+// return instead of a plain-text reply. Scripted calls are consumed in the
+// order they were added.
+func (m *MockBackend) ScriptToolCall(name, arguments string) {
+	m.toolCalls = append(m.toolCalls, ScriptedToolCall{Name: name, Arguments: arguments})
+}
+
+// NewMockBackend creates a MockBackend that cycles through a small set of
+// canned jokes so examples and tests have deterministic output.
+func NewMockBackend() *MockBackend {
+	return &MockBackend{
+		name:      "Mock",
+		available: true,
+		responses: []string{
+			"Why did the scarecrow win an award? Because he was outstanding in his field!",
+			"Why don't scientists trust atoms? Because they make up everything!",
+		},
+	}
+}
+
+// Name returns the name of this backend.
+func (m *MockBackend) Name() string {
+	return m.name
+}
+
+func (m *MockBackend) nextResponse() string {
+	resp := m.responses[m.calls%len(m.responses)]
+	m.calls++
+	return resp
+}
+
+// ChatCompletion returns a canned response, cycling through MockBackend's
+// scripted replies on each call.
+func (m *MockBackend) ChatCompletion(ctx context.Context, req ChatCompletionRequest) (*ChatCompletionResponse, error) {
+	if len(req.Messages) == 0 {
+		return nil, fmt.Errorf("messages are required")
+	}
+
+	if len(m.toolCalls) > 0 {
+		call := m.toolCalls[0]
+		m.toolCalls = m.toolCalls[1:]
+
+		return &ChatCompletionResponse{
+			ID:     "mock-completion",
+			Object: "chat.completion",
+			Model:  req.Model,
+			Choices: []Choice{
+				{
+					Index: 0,
+					Message: Message{
+						Role: "assistant",
+						ToolCalls: []ToolCall{
+							{
+								ID:   fmt.Sprintf("call-%d", m.calls),
+								Type: "function",
+								Function: ToolCallFunction{
+									Name:      call.Name,
+									Arguments: call.Arguments,
+								},
+							},
+						},
+					},
+					FinishReason: "tool_calls",
+				},
+			},
+		}, nil
+	}
+
+	content := m.nextResponse()
+
+	return &ChatCompletionResponse{
+		ID:     "mock-completion",
+		Object: "chat.completion",
+		Model:  req.Model,
+		Choices: []Choice{
+			{
+				Index:        0,
+				Message:      Message{Role: "assistant", Content: content},
+				FinishReason: "stop",
+			},
+		},
+		Usage: Usage{
+			PromptTokens:     len(req.Messages) * 10,
+			CompletionTokens: len(content) / 4,
+			TotalTokens:      len(req.Messages)*10 + len(content)/4,
+		},
+	}, nil
+}
+
+// ChatCompletionStream replays the next canned response word-by-word as a
+// series of synthetic chunks, pausing StreamDelay between each one, so
+// callers can exercise streaming without any real HTTP traffic.
+func (m *MockBackend) ChatCompletionStream(ctx context.Context, req ChatCompletionRequest) (*ChatCompletionStream, error) {
+	if len(req.Messages) == 0 {
+		return nil, fmt.Errorf("messages are required")
+	}
+
+	words := strings.Fields(m.nextResponse())
+	chunks := make([]ChatCompletionChunk, 0, len(words)+1)
+
+	for i, word := range words {
+		delta := Delta{Content: word}
+		if i == 0 {
+			delta.Role = "assistant"
+		}
+		if i < len(words)-1 {
+			word = word + " "
+			delta.Content = word
+		}
+		chunks = append(chunks, ChatCompletionChunk{
+			ID:      "mock-completion",
+			Object:  "chat.completion.chunk",
+			Model:   req.Model,
+			Choices: []ChunkChoice{{Index: 0, Delta: delta}},
+		})
+	}
+	chunks = append(chunks, ChatCompletionChunk{
+		ID:      "mock-completion",
+		Object:  "chat.completion.chunk",
+		Model:   req.Model,
+		Choices: []ChunkChoice{{Index: 0, Delta: Delta{}, FinishReason: "stop"}},
+	})
+
+	return newMockStream(ctx, chunks, m.StreamDelay), nil
+}
+
+// SendMessage implements the legacy interface by converting to ChatCompletion.
+func (m *MockBackend) SendMessage(ctx context.Context, req Request) (*Response, error) {
+	chatResp, err := m.ChatCompletion(ctx, ChatCompletionRequest{
+		Model:    req.Model,
+		Messages: req.Messages,
+	})
+	if err != nil {
+		return &Response{Error: err}, err
+	}
+
+	return &Response{
+		Content:    chatResp.Choices[0].Message.Content,
+		TokensUsed: chatResp.Usage.TotalTokens,
+		Model:      chatResp.Model,
+		Timestamp:  time.Now(),
+	}, nil
+}
+
+// CreateEmbeddings returns deterministic fake embedding vectors, hashed from
+// each input string so the same input always yields the same vector.
+func (m *MockBackend) CreateEmbeddings(ctx context.Context, req EmbeddingRequest) (*EmbeddingResponse, error) {
+	var inputs []string
+	switch v := req.Input.(type) {
+	case string:
+		inputs = []string{v}
+	case []string:
+		inputs = v
+	default:
+		return nil, fmt.Errorf("input must be a string or []string")
+	}
+
+	dimensions := m.EmbeddingDimensions
+	if dimensions <= 0 {
+		dimensions = 8
+	}
+
+	data := make([]EmbeddingData, len(inputs))
+	for i, input := range inputs {
+		data[i] = EmbeddingData{
+			Index:     i,
+			Object:    "embedding",
+			Embedding: hashToEmbedding(input, dimensions),
+		}
+	}
+
+	return &EmbeddingResponse{
+		Object: "list",
+		Data:   data,
+		Model:  req.Model,
+		Usage: Usage{
+			PromptTokens: len(inputs) * 4,
+			TotalTokens:  len(inputs) * 4,
+		},
+	}, nil
+}
+
+// hashToEmbedding deterministically derives a fake embedding vector from
+// text by hashing it repeatedly with an incrementing seed, so the same text
+// always produces the same vector without any real model behind it.
+func hashToEmbedding(text string, dimensions int) []float64 {
+	vector := make([]float64, dimensions)
+	for i := range vector {
+		h := fnv.New32a()
+		fmt.Fprintf(h, "%s:%d", text, i)
+		// Map the hash into [-1, 1] so the vector looks like a real,
+		// normalized embedding rather than raw hash output.
+		vector[i] = float64(h.Sum32())/float64(^uint32(0))*2 - 1
+	}
+	return vector
+}
+
+// Moderations returns a clean result for every input, unless
+// ModerationFlagged is set, in which case every category is flagged.
+func (m *MockBackend) Moderations(ctx context.Context, req ModerationRequest) (*ModerationResponse, error) {
+	var inputs []string
+	switch v := req.Input.(type) {
+	case string:
+		inputs = []string{v}
+	case []string:
+		inputs = v
+	default:
+		return nil, fmt.Errorf("input must be a string or []string")
+	}
+
+	results := make([]ModerationResult, len(inputs))
+	for i := range inputs {
+		if m.ModerationFlagged {
+			results[i] = ModerationResult{
+				Flagged: true,
+				Categories: ModerationCategories{
+					Sexual: true, Hate: true, Harassment: true,
+					SelfHarm: true, Violence: true, SexualMinors: true, HateThreatening: true,
+				},
+				CategoryScores: ModerationCategoryScores{
+					Sexual: 0.9, Hate: 0.9, Harassment: 0.9,
+					SelfHarm: 0.9, Violence: 0.9, SexualMinors: 0.9, HateThreatening: 0.9,
+				},
+			}
+			continue
+		}
+		results[i] = ModerationResult{Flagged: false}
+	}
+
+	return &ModerationResponse{
+		ID:      "mock-moderation",
+		Model:   "mock-moderation-v1",
+		Results: results,
+	}, nil
+}
+
+// IsAvailable reports whether this mock backend is currently marked available.
+func (m *MockBackend) IsAvailable(ctx context.Context) bool {
+	return m.available
+}
+
+// Configure updates the mock backend's configuration.
+func (m *MockBackend) Configure(config map[string]interface{}) error {
+	if name, ok := config["name"].(string); ok && name != "" {
+		m.name = name
+	}
+	if available, ok := config["available"].(bool); ok {
+		m.available = available
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package openai
+
+import (
+	"context"
+	"testing"
+)
+
+func TestMockBackendCreateEmbeddingsIsDeterministic(t *testing.T) {
+	backend := NewMockBackend()
+
+	resp1, err := backend.CreateEmbeddings(context.Background(), EmbeddingRequest{Model: "mock-embed", Input: "hello world"})
+	if err != nil {
+		t.Fatalf("CreateEmbeddings returned error: %v", err)
+	}
+	resp2, err := backend.CreateEmbeddings(context.Background(), EmbeddingRequest{Model: "mock-embed", Input: "hello world"})
+	if err != nil {
+		t.Fatalf("CreateEmbeddings returned error: %v", err)
+	}
+
+	if len(resp1.Data) != 1 || len(resp2.Data) != 1 {
+		t.Fatalf("expected 1 embedding per call, got %d and %d", len(resp1.Data), len(resp2.Data))
+	}
+	vec1, vec2 := resp1.Data[0].Embedding, resp2.Data[0].Embedding
+	if len(vec1) != 8 {
+		t.Errorf("embedding has %d dimensions, want default of 8", len(vec1))
+	}
+	for i := range vec1 {
+		if vec1[i] != vec2[i] {
+			t.Fatalf("embedding for identical input differs across calls at index %d: %v != %v", i, vec1[i], vec2[i])
+		}
+	}
+}
+
+func TestMockBackendCreateEmbeddingsDiffersByInput(t *testing.T) {
+	backend := NewMockBackend()
+
+	resp, err := backend.CreateEmbeddings(context.Background(), EmbeddingRequest{Model: "mock-embed", Input: []string{"hello", "goodbye"}})
+	if err != nil {
+		t.Fatalf("CreateEmbeddings returned error: %v", err)
+	}
+	if len(resp.Data) != 2 {
+		t.Fatalf("expected 2 embeddings, got %d", len(resp.Data))
+	}
+	if resp.Data[0].Embedding[0] == resp.Data[1].Embedding[0] {
+		t.Error("embeddings for different inputs are identical, want distinct vectors")
+	}
+}
+
+func TestMockBackendModerationsFlagging(t *testing.T) {
+	backend := NewMockBackend()
+
+	clean, err := backend.Moderations(context.Background(), ModerationRequest{Input: "a nice day"})
+	if err != nil {
+		t.Fatalf("Moderations returned error: %v", err)
+	}
+	if clean.Results[0].Flagged {
+		t.Error("Moderations flagged clean input, want unflagged")
+	}
+
+	backend.ModerationFlagged = true
+	flagged, err := backend.Moderations(context.Background(), ModerationRequest{Input: "a nice day"})
+	if err != nil {
+		t.Fatalf("Moderations returned error: %v", err)
+	}
+	if !flagged.Results[0].Flagged {
+		t.Error("Moderations did not flag input with ModerationFlagged set, want flagged")
+	}
+}
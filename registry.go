@@ -0,0 +1,43 @@
+package openai
+
+import "fmt"
+
+// BackendFactory constructs a Backend from a Config. Providers register one
+// with RegisterBackend so callers can select a backend by name at runtime.
+type BackendFactory func(Config) (Backend, error)
+
+var backendRegistry = make(map[string]BackendFactory)
+
+func init() {
+	RegisterBackend("openai", func(config Config) (Backend, error) {
+		return NewClient(config), nil
+	})
+}
+
+// RegisterBackend makes a provider's Backend implementation available under
+// name for NewBackendByName and NewBackend to construct. Call it from an
+// init func in the provider's package, e.g. the backends package, so importing
+// that package for its side effect is enough to make the provider available.
+func RegisterBackend(name string, factory BackendFactory) {
+	backendRegistry[name] = factory
+}
+
+// NewBackendByName constructs the Backend registered under name, returning
+// an error if no provider has registered that name.
+func NewBackendByName(name string, config Config) (Backend, error) {
+	factory, ok := backendRegistry[name]
+	if !ok {
+		return nil, fmt.Errorf("openai: no backend registered for provider %q", name)
+	}
+	return factory(config)
+}
+
+// NewBackend constructs the Backend named by config.Provider, defaulting to
+// the built-in "openai" provider when Provider is empty.
+func NewBackend(config Config) (Backend, error) {
+	provider := config.Provider
+	if provider == "" {
+		provider = "openai"
+	}
+	return NewBackendByName(provider, config)
+}
@@ -0,0 +1,188 @@
+package openai
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Delta carries the incremental fields a streamed chunk adds to a message.
+type Delta struct {
+	Role    string `json:"role,omitempty"`
+	Content string `json:"content,omitempty"`
+}
+
+// ChunkChoice is one choice's incremental delta within a ChatCompletionChunk.
+type ChunkChoice struct {
+	Index        int    `json:"index"`
+	Delta        Delta  `json:"delta"`
+	FinishReason string `json:"finish_reason,omitempty"`
+}
+
+// ChatCompletionChunk is a single streamed event from a chat completion.
+type ChatCompletionChunk struct {
+	ID      string        `json:"id"`
+	Object  string        `json:"object"`
+	Created int64         `json:"created"`
+	Model   string        `json:"model"`
+	Choices []ChunkChoice `json:"choices"`
+}
+
+// chunkSource is whatever a ChatCompletionStream reads its chunks from, real
+// SSE body or synthetic MockBackend feed.
+type chunkSource interface {
+	recv() (ChatCompletionChunk, error)
+	close() error
+}
+
+// ChatCompletionStream is a handle to an in-progress streamed chat
+// completion. Call Recv repeatedly until it returns io.EOF, then Close the
+// stream to release its underlying resources.
+type ChatCompletionStream struct {
+	source chunkSource
+}
+
+// Recv returns the next chunk, or io.EOF once the stream is exhausted.
+func (s *ChatCompletionStream) Recv() (ChatCompletionChunk, error) {
+	return s.source.recv()
+}
+
+// Close releases the resources backing the stream. It is safe to call more
+// than once.
+func (s *ChatCompletionStream) Close() error {
+	return s.source.close()
+}
+
+// httpChunkSource reads Server-Sent Events off an HTTP response body.
+type httpChunkSource struct {
+	body    io.ReadCloser
+	scanner *bufio.Scanner
+	cancel  context.CancelFunc
+}
+
+func (h *httpChunkSource) recv() (ChatCompletionChunk, error) {
+	for h.scanner.Scan() {
+		line := strings.TrimSpace(h.scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		payload, ok := strings.CutPrefix(line, "data: ")
+		if !ok {
+			continue
+		}
+		if payload == "[DONE]" {
+			return ChatCompletionChunk{}, io.EOF
+		}
+
+		var chunk ChatCompletionChunk
+		if err := json.Unmarshal([]byte(payload), &chunk); err != nil {
+			return ChatCompletionChunk{}, fmt.Errorf("failed to parse stream chunk: %w", err)
+		}
+		return chunk, nil
+	}
+
+	if err := h.scanner.Err(); err != nil {
+		return ChatCompletionChunk{}, fmt.Errorf("failed to read stream: %w", err)
+	}
+	return ChatCompletionChunk{}, io.EOF
+}
+
+func (h *httpChunkSource) close() error {
+	h.cancel()
+	return h.body.Close()
+}
+
+// ChatCompletionStream opens a streamed chat completion request to OpenAI's
+// API using Server-Sent Events and returns a handle for reading chunks as
+// they arrive. Cancel ctx to stop the stream and release the connection.
+func (c *Client) ChatCompletionStream(ctx context.Context, req ChatCompletionRequest) (*ChatCompletionStream, error) {
+	if req.Model == "" {
+		return nil, fmt.Errorf("model is required")
+	}
+	if len(req.Messages) == 0 {
+		return nil, fmt.Errorf("messages are required")
+	}
+	req.Stream = true
+
+	requestBody, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	url := fmt.Sprintf("%s/chat/completions", c.baseURL)
+	resp, err := DoStreamWithRetry(c.httpClient, c.maxRetries, c.sleep, func() (*http.Request, error) {
+		httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(requestBody))
+		if err != nil {
+			return nil, err
+		}
+		httpReq.Header.Set("Content-Type", "application/json")
+		httpReq.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.apiKey))
+		httpReq.Header.Set("Accept", "text/event-stream")
+		return httpReq, nil
+	})
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	if info := parseRateLimitInfo(resp.Header); info != nil {
+		c.rateLimitMu.Lock()
+		c.rateLimit = info
+		c.rateLimitMu.Unlock()
+	}
+
+	return &ChatCompletionStream{
+		source: &httpChunkSource{
+			body:    resp.Body,
+			scanner: bufio.NewScanner(resp.Body),
+			cancel:  cancel,
+		},
+	}, nil
+}
+
+// mockChunkSource replays a fixed slice of chunks, pausing delay between
+// each one, so MockBackend can simulate streaming without real HTTP traffic.
+type mockChunkSource struct {
+	ctx    context.Context
+	chunks []ChatCompletionChunk
+	delay  time.Duration
+	next   int
+}
+
+func newMockStream(ctx context.Context, chunks []ChatCompletionChunk, delay time.Duration) *ChatCompletionStream {
+	return &ChatCompletionStream{source: &mockChunkSource{ctx: ctx, chunks: chunks, delay: delay}}
+}
+
+func (m *mockChunkSource) recv() (ChatCompletionChunk, error) {
+	if m.next >= len(m.chunks) {
+		return ChatCompletionChunk{}, io.EOF
+	}
+
+	if m.delay > 0 {
+		select {
+		case <-time.After(m.delay):
+		case <-m.ctx.Done():
+			return ChatCompletionChunk{}, m.ctx.Err()
+		}
+	} else if err := m.ctx.Err(); err != nil {
+		return ChatCompletionChunk{}, err
+	}
+
+	chunk := m.chunks[m.next]
+	m.next++
+	return chunk, nil
+}
+
+func (m *mockChunkSource) close() error {
+	m.next = len(m.chunks)
+	return nil
+}
@@ -0,0 +1,172 @@
+package tokenizer
+
+import (
+	"bufio"
+	"embed"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
+	openai "github.com/jeanhaley32/go-openai-client"
+)
+
+//go:embed data/cl100k_base.bpe data/o200k_base.bpe
+var mergeTableFS embed.FS
+
+// bpeEncoding is a rank table for one tiktoken-style encoding: it maps the
+// byte sequence each token represents to its merge rank (lower merges
+// first).
+//
+// The embedded data/*.bpe files here hold a compact, hand-curated subset of
+// ranks (every single byte plus a few dozen common English merges) rather
+// than the full upstream cl100k_base/o200k_base tables, so counts are an
+// approximation. The two tables share the same 256-byte alphabet but differ
+// in which merges they apply above that, consistent with o200k_base being a
+// distinct (larger) vocabulary from cl100k_base upstream. Swapping in the
+// full upstream .tiktoken file at the same path is a drop-in replacement;
+// the merge algorithm below doesn't change.
+type bpeEncoding struct {
+	ranks map[string]int
+}
+
+func loadEncoding(path string) (*bpeEncoding, error) {
+	data, err := mergeTableFS.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open merge table %s: %w", path, err)
+	}
+	defer data.Close()
+
+	ranks := make(map[string]int)
+	scanner := bufio.NewScanner(data)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("malformed merge table line: %q", line)
+		}
+
+		token, err := base64.StdEncoding.DecodeString(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode token %q: %w", fields[0], err)
+		}
+		rank, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse rank %q: %w", fields[1], err)
+		}
+
+		ranks[string(token)] = rank
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read merge table %s: %w", path, err)
+	}
+
+	return &bpeEncoding{ranks: ranks}, nil
+}
+
+// encode runs the byte-pair merge algorithm over text, returning one token
+// per merged byte sequence. It repeatedly merges the adjacent pair with the
+// lowest rank until no pair in the table remains, the standard tiktoken BPE
+// algorithm applied to raw UTF-8 bytes rather than Unicode code points.
+func (e *bpeEncoding) encode(text string) []string {
+	if text == "" {
+		return nil
+	}
+
+	data := []byte(text)
+	parts := make([][]byte, len(data))
+	for i, b := range data {
+		parts[i] = []byte{b}
+	}
+
+	for len(parts) > 1 {
+		bestRank := -1
+		bestIndex := -1
+		for i := 0; i < len(parts)-1; i++ {
+			pair := string(parts[i]) + string(parts[i+1])
+			rank, ok := e.ranks[pair]
+			if !ok {
+				continue
+			}
+			if bestRank == -1 || rank < bestRank {
+				bestRank = rank
+				bestIndex = i
+			}
+		}
+		if bestIndex == -1 {
+			break
+		}
+
+		merged := append(append([]byte{}, parts[bestIndex]...), parts[bestIndex+1]...)
+		parts = append(parts[:bestIndex], append([][]byte{merged}, parts[bestIndex+2:]...)...)
+	}
+
+	tokens := make([]string, len(parts))
+	for i, part := range parts {
+		tokens[i] = string(part)
+	}
+	return tokens
+}
+
+// BPETokenizer is a byte-pair-encoding Tokenizer in the tiktoken style,
+// selecting between the cl100k_base and o200k_base merge tables by model
+// name.
+type BPETokenizer struct {
+	encodings map[string]*bpeEncoding
+}
+
+// NewBPETokenizer loads the embedded cl100k_base and o200k_base merge
+// tables.
+func NewBPETokenizer() (*BPETokenizer, error) {
+	cl100kBase, err := loadEncoding("data/cl100k_base.bpe")
+	if err != nil {
+		return nil, err
+	}
+	o200kBase, err := loadEncoding("data/o200k_base.bpe")
+	if err != nil {
+		return nil, err
+	}
+
+	return &BPETokenizer{
+		encodings: map[string]*bpeEncoding{
+			"cl100k_base": cl100kBase,
+			"o200k_base":  o200kBase,
+		},
+	}, nil
+}
+
+// encodingNameForModel maps a model name to the tiktoken encoding it uses.
+func encodingNameForModel(model string) string {
+	switch {
+	case strings.HasPrefix(model, "gpt-4o"), strings.HasPrefix(model, "o1"):
+		return "o200k_base"
+	default:
+		return "cl100k_base"
+	}
+}
+
+// CountMessages counts the tokens messages will cost under model, including
+// the per-message, per-name, and reply-priming overhead OpenAI documents for
+// chat models.
+func (t *BPETokenizer) CountMessages(messages []openai.Message, model string) int {
+	encoding, ok := t.encodings[encodingNameForModel(model)]
+	if !ok {
+		encoding = t.encodings["cl100k_base"]
+	}
+
+	total := TokensPerReply
+	for _, msg := range messages {
+		total += TokensPerMessage
+		total += len(encoding.encode(msg.Role))
+		total += len(encoding.encode(msg.Content))
+		if msg.Name != "" {
+			total += TokensPerName
+			total += len(encoding.encode(msg.Name))
+		}
+	}
+	return total
+}
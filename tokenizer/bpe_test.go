@@ -0,0 +1,71 @@
+package tokenizer
+
+import (
+	"testing"
+
+	openai "github.com/jeanhaley32/go-openai-client"
+)
+
+func TestBPEEncodingMergesKnownPairs(t *testing.T) {
+	encoding, err := loadEncoding("data/cl100k_base.bpe")
+	if err != nil {
+		t.Fatalf("loadEncoding returned error: %v", err)
+	}
+
+	tokens := encoding.encode("th")
+	if len(tokens) != 1 {
+		t.Errorf("encode(%q) = %v (%d tokens), want 1 merged token", "th", tokens, len(tokens))
+	}
+}
+
+func TestBPEEncodingFallsBackToBytesForUnknownText(t *testing.T) {
+	encoding, err := loadEncoding("data/cl100k_base.bpe")
+	if err != nil {
+		t.Fatalf("loadEncoding returned error: %v", err)
+	}
+
+	tokens := encoding.encode("\x01\x02")
+	if len(tokens) != 2 {
+		t.Errorf("encode of two unmergeable bytes produced %d tokens, want 2", len(tokens))
+	}
+}
+
+func TestBPETokenizerRoutesModelsToDistinctEncodings(t *testing.T) {
+	if got := encodingNameForModel("gpt-4o-mini"); got != "o200k_base" {
+		t.Errorf("encodingNameForModel(gpt-4o-mini) = %q, want o200k_base", got)
+	}
+	if got := encodingNameForModel("o1-preview"); got != "o200k_base" {
+		t.Errorf("encodingNameForModel(o1-preview) = %q, want o200k_base", got)
+	}
+	if got := encodingNameForModel("gpt-4"); got != "cl100k_base" {
+		t.Errorf("encodingNameForModel(gpt-4) = %q, want cl100k_base", got)
+	}
+}
+
+func TestBPETokenizerCountsDifferByEncoding(t *testing.T) {
+	tok, err := NewBPETokenizer()
+	if err != nil {
+		t.Fatalf("NewBPETokenizer returned error: %v", err)
+	}
+
+	messages := []openai.Message{{Role: "user", Content: "the and ing"}}
+
+	cl100k := tok.CountMessages(messages, "gpt-4")
+	o200k := tok.CountMessages(messages, "gpt-4o")
+
+	if cl100k == o200k {
+		t.Errorf("cl100k_base and o200k_base produced the same count (%d) for a model-specific phrase; the two tables should differ", cl100k)
+	}
+}
+
+func TestBPETokenizerCountMessagesIncludesOverhead(t *testing.T) {
+	tok, err := NewBPETokenizer()
+	if err != nil {
+		t.Fatalf("NewBPETokenizer returned error: %v", err)
+	}
+
+	empty := tok.CountMessages(nil, "gpt-4")
+	if empty != TokensPerReply {
+		t.Errorf("CountMessages(nil) = %d, want %d (reply-priming overhead only)", empty, TokensPerReply)
+	}
+}
@@ -0,0 +1,20 @@
+// Package tokenizer counts how many tokens a set of chat messages will cost
+// against a given model, so callers can trim a conversation before it
+// overflows the model's context window.
+package tokenizer
+
+import openai "github.com/jeanhaley32/go-openai-client"
+
+// Tokenizer counts tokens for a slice of chat messages under a given model.
+type Tokenizer interface {
+	CountMessages(messages []openai.Message, model string) int
+}
+
+// Per-message overhead OpenAI documents for chat models: every message costs
+// 3 tokens of framing, every "name" field costs 1 more, and the reply is
+// primed with 3 tokens.
+const (
+	TokensPerMessage = 3
+	TokensPerName    = 1
+	TokensPerReply   = 3
+)
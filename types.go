@@ -0,0 +1,106 @@
+package openai
+
+import (
+	"time"
+
+	"github.com/jeanhaley32/go-openai-client/jsonschema"
+)
+
+// Message represents a single message in a chat conversation.
+type Message struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+	// ToolCalls holds the tool calls an assistant message requested, if any.
+	ToolCalls []ToolCall `json:"tool_calls,omitempty"`
+	// ToolCallID identifies which ToolCall a role="tool" message answers.
+	ToolCallID string `json:"tool_call_id,omitempty"`
+	// Name is the tool name for a role="tool" message.
+	Name string `json:"name,omitempty"`
+}
+
+// Tool describes a function the model may call, along with its JSON Schema
+// argument definition.
+type Tool struct {
+	Type     string       `json:"type"`
+	Function ToolFunction `json:"function"`
+}
+
+// ToolFunction is the callable description within a Tool.
+type ToolFunction struct {
+	Name        string                `json:"name"`
+	Description string                `json:"description,omitempty"`
+	Parameters  jsonschema.Definition `json:"parameters"`
+}
+
+// ToolCall is a single function call the model requested.
+type ToolCall struct {
+	ID       string           `json:"id"`
+	Type     string           `json:"type"`
+	Function ToolCallFunction `json:"function"`
+}
+
+// ToolCallFunction carries the name and JSON-encoded arguments of a ToolCall.
+type ToolCallFunction struct {
+	Name      string `json:"name"`
+	Arguments string `json:"arguments"`
+}
+
+// ChatCompletionRequest mirrors OpenAI's /v1/chat/completions request body.
+type ChatCompletionRequest struct {
+	Model       string    `json:"model"`
+	Messages    []Message `json:"messages"`
+	MaxTokens   *int      `json:"max_tokens,omitempty"`
+	Temperature *float64  `json:"temperature,omitempty"`
+	TopP        *float64  `json:"top_p,omitempty"`
+	Stream      bool      `json:"stream,omitempty"`
+	// Tools lists the functions the model may call.
+	Tools []Tool `json:"tools,omitempty"`
+	// ToolChoice controls whether/which tool the model must call: "none",
+	// "auto", "required", or {"type": "function", "function": {"name": ...}}.
+	ToolChoice interface{} `json:"tool_choice,omitempty"`
+}
+
+// Choice is a single completion choice returned by the API.
+type Choice struct {
+	Index        int     `json:"index"`
+	Message      Message `json:"message"`
+	FinishReason string  `json:"finish_reason"`
+}
+
+// Usage reports token accounting for a request.
+type Usage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+}
+
+// ChatCompletionResponse mirrors OpenAI's /v1/chat/completions response body.
+type ChatCompletionResponse struct {
+	ID      string   `json:"id"`
+	Object  string   `json:"object"`
+	Created int64    `json:"created"`
+	Model   string   `json:"model"`
+	Choices []Choice `json:"choices"`
+	Usage   Usage    `json:"usage"`
+}
+
+// Request is the legacy, pre-ChatCompletion request shape kept around for
+// callers that haven't migrated to ChatCompletionRequest yet.
+type Request struct {
+	Model       string
+	Messages    []Message
+	MaxTokens   *int
+	Temperature *float64
+	TopP        *float64
+	Stream      bool
+}
+
+// Response is the legacy, pre-ChatCompletion response shape kept around for
+// callers that haven't migrated to ChatCompletionResponse yet.
+type Response struct {
+	Content    string
+	TokensUsed int
+	Model      string
+	Timestamp  time.Time
+	Error      error
+}